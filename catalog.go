@@ -1,8 +1,12 @@
 package lxn
 
 import (
+	"cmp"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/mprot/msgpack-go"
 
@@ -10,11 +14,32 @@ import (
 )
 
 // Catalog is a container that holds messages for a locale. The locale itself
-// is referenced by its id only. Each message is identified by a unique key
-// which consists of the section and the message key within the lxn file.
+// is referenced by its id only. Each message is identified by its section and
+// the message key within the lxn file.
 type Catalog struct {
 	localeID string
-	msgs     map[string]*Message // message's unique key => message
+	msgs     messageMap
+}
+
+// messageMap holds the messages grouped by section, so a lookup does not need to
+// build a combined key.
+type messageMap map[string]map[string]*Message // section => message key => message
+
+// add adds the message to the map. If a message with the same section and key
+// already exists, it will be replaced and false is returned.
+func (m messageMap) add(msg *Message) bool {
+	section := m[msg.Section()]
+	if section == nil {
+		section = map[string]*Message{}
+		m[msg.Section()] = section
+	}
+	_, dup := section[msg.Key()]
+	section[msg.Key()] = msg
+	return !dup
+}
+
+func (m messageMap) lookup(section string, key string) *Message {
+	return m[section][key]
 }
 
 // ReadCatalog reads a catalog from the given binary stream.
@@ -23,50 +48,160 @@ func ReadCatalog(r io.Reader) (*Catalog, error) {
 	if err := msgpack.Decode(r, cat); err != nil {
 		return nil, err
 	}
-	return newCatalog(cat.LocaleID, cat.Messages), nil
+	return newCatalog(cat.LocaleID, cat.Messages)
 }
 
-func newCatalog(localeID string, messages []lxn.Message) *Catalog {
-	msgs := make(map[string]*Message, len(messages))
+// newCatalog creates a catalog from the given messages. If multiple messages
+// share the same section and key, a DuplicateMessageError with all duplicates
+// will be returned.
+func newCatalog(localeID string, messages []lxn.Message) (*Catalog, error) {
+	msgs := messageMap{}
+	var dups []MessageKey
 	for _, m := range messages {
-		msg := newMessage(m)
-		msgs[uniqMessageKey(msg.Section(), msg.Key())] = msg
+		if msgs.add(newMessage(m)) {
+			continue
+		}
+		key := MessageKey{Section: m.Section, Key: m.Key}
+		if !slices.Contains(dups, key) {
+			dups = append(dups, key)
+		}
+	}
+	if len(dups) != 0 {
+		return nil, &DuplicateMessageError{LocaleID: localeID, Keys: dups}
 	}
 
 	return &Catalog{
 		localeID: localeID,
 		msgs:     msgs,
-	}
+	}, nil
 }
 
 // MergeCatalogs merges all catalogs into a single one. All given
-// catalogs must have the same locale id. If there are duplicates
-// in the message key, they will be overwritten.
+// catalogs must have the same locale id. If a message is defined in
+// more than one catalog, a DuplicateMessageError listing all of these
+// messages is returned. Use MergeCatalogsWith to resolve duplicates.
 //
 // Note: If no catalog is passed as an argument, nil will be returned.
 func MergeCatalogs(catalogs ...*Catalog) (*Catalog, error) {
-	switch len(catalogs) {
-	case 0:
-		return nil, nil
-	case 1:
+	if len(catalogs) == 1 {
 		return catalogs[0], nil
 	}
+	cat, report, err := MergeCatalogsWith(LastWins, catalogs...)
+	switch {
+	case err != nil:
+		return nil, err
+	case len(report.Overrides) != 0:
+		keys := make([]MessageKey, 0, len(report.Overrides))
+		for _, override := range report.Overrides {
+			keys = append(keys, override.Key)
+		}
+		return nil, &DuplicateMessageError{LocaleID: cat.localeID, Keys: keys}
+	}
+	return cat, nil
+}
+
+// MergeCatalogsWith merges all catalogs into a single one and resolves conflicting
+// messages with the given resolver. All given catalogs must have the same locale
+// id. The returned report lists every message which is defined in more than one
+// catalog.
+//
+// Note: If no catalog is passed as an argument, nil will be returned.
+func MergeCatalogsWith(resolve Resolver, catalogs ...*Catalog) (*Catalog, *MergeReport, error) {
+	report := &MergeReport{}
+	if len(catalogs) == 0 {
+		return nil, report, nil
+	}
 
 	localeID := catalogs[0].localeID
-	msgs := map[string]*Message{}
-	for _, cat := range catalogs {
-		if localeID != cat.localeID {
-			return nil, fmt.Errorf("multiple locales detected: %s and %s", localeID, cat.localeID)
+	msgs := messageMap{}
+	owners := map[MessageKey]int{}
+	overrides := map[MessageKey]*MergeOverride{}
+	for i, cat := range catalogs {
+		if !sameLocale(localeID, cat.localeID) {
+			return nil, nil, fmt.Errorf("multiple locales detected: %s and %s", localeID, cat.localeID)
 		}
-		for key, msg := range cat.msgs {
-			msgs[key] = msg
+		for _, section := range cat.msgs {
+			for _, msg := range section {
+				key := MessageKey{Section: msg.Section(), Key: msg.Key()}
+				prev := msgs.lookup(key.Section, key.Key)
+				if prev == nil {
+					msgs.add(msg)
+					owners[key] = i
+					continue
+				}
+
+				override := overrides[key]
+				if override == nil {
+					override = &MergeOverride{Key: key, Catalogs: []int{owners[key]}}
+					overrides[key] = override
+				}
+				override.Catalogs = append(override.Catalogs, i)
+
+				kept, err := resolve(key, prev, msg)
+				switch {
+				case err != nil:
+					return nil, nil, err
+				case kept == msg:
+					msgs.add(msg)
+					owners[key] = i
+				case kept != prev:
+					return nil, nil, fmt.Errorf("conflict resolver returned an unknown message for %s", key)
+				}
+				override.Winner = owners[key]
+			}
 		}
 	}
 
+	report.Overrides = make([]MergeOverride, 0, len(overrides))
+	for _, override := range overrides {
+		report.Overrides = append(report.Overrides, *override)
+	}
+	slices.SortFunc(report.Overrides, func(x, y MergeOverride) int {
+		if c := cmp.Compare(x.Key.Section, y.Key.Section); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.Key.Key, y.Key.Key)
+	})
+
 	return &Catalog{
 		localeID: localeID,
 		msgs:     msgs,
-	}, nil
+	}, report, nil
+}
+
+// Resolver decides which message is kept if two catalogs contain a message with
+// the same key. The messages are passed in catalog order, i.e. prev stems from an
+// earlier catalog than next. The resolver has to return one of both messages or
+// an error, which aborts the merge.
+type Resolver func(key MessageKey, prev, next *Message) (*Message, error)
+
+// LastWins is a resolver which keeps the message of the later catalog.
+func LastWins(key MessageKey, prev, next *Message) (*Message, error) {
+	return next, nil
+}
+
+// FirstWins is a resolver which keeps the message of the earlier catalog.
+func FirstWins(key MessageKey, prev, next *Message) (*Message, error) {
+	return prev, nil
+}
+
+// ErrorOnConflict is a resolver which fails for any conflicting message.
+func ErrorOnConflict(key MessageKey, prev, next *Message) (*Message, error) {
+	return nil, fmt.Errorf("conflicting messages for %s", key)
+}
+
+// MergeReport holds the result of merging catalogs.
+type MergeReport struct {
+	Overrides []MergeOverride // sorted by section and key
+}
+
+// MergeOverride describes a message which is defined in more than one of the
+// merged catalogs. The catalogs are referenced by their index in the argument
+// list of the merge.
+type MergeOverride struct {
+	Key      MessageKey
+	Catalogs []int // catalogs which define the message
+	Winner   int   // catalog whose message was kept
 }
 
 func (c *Catalog) LocaleID() string {
@@ -78,9 +213,39 @@ func (c *Catalog) LocaleID() string {
 // needs to be empty. If the message with the given key cannot be found,
 // nil will be returned.
 func (c *Catalog) Message(section string, key string) *Message {
-	return c.msgs[uniqMessageKey(section, key)]
+	return c.msgs.lookup(section, key)
+}
+
+// MessageKey identifies a message by its section and its key within the section.
+type MessageKey struct {
+	Section string
+	Key     string
 }
 
-func uniqMessageKey(section, key string) string {
-	return section + "." + key
+func (k MessageKey) String() string {
+	if k.Section == "" {
+		return strconv.Quote(k.Key)
+	}
+	return strconv.Quote(k.Section) + "." + strconv.Quote(k.Key)
+}
+
+// DuplicateMessageError is returned when a catalog contains multiple messages
+// with the same section and key.
+type DuplicateMessageError struct {
+	LocaleID string
+	Keys     []MessageKey // each duplicated key is listed once
+}
+
+func (e *DuplicateMessageError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("duplicate messages in catalog ")
+	sb.WriteString(e.LocaleID)
+	sb.WriteString(": ")
+	for i, key := range e.Keys {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(key.String())
+	}
+	return sb.String()
 }
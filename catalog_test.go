@@ -0,0 +1,171 @@
+package lxn
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestNewCatalog(t *testing.T) {
+	cat, err := newCatalog("en", []lxn.Message{
+		{Section: "a.b", Key: "c", Text: []string{"a.b/c"}},
+		{Section: "a", Key: "b.c", Text: []string{"a/b.c"}},
+		{Section: "", Key: "a.b.c", Text: []string{"/a.b.c"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []MessageKey{{"a.b", "c"}, {"a", "b.c"}, {"", "a.b.c"}} {
+		msg := cat.Message(key.Section, key.Key)
+		switch {
+		case msg == nil:
+			t.Errorf("message %s not found", key)
+		case msg.Section() != key.Section || msg.Key() != key.Key:
+			t.Errorf("unexpected message for %s: %s", key, MessageKey{msg.Section(), msg.Key()})
+		}
+	}
+}
+
+func TestNewCatalogWithDuplicates(t *testing.T) {
+	_, err := newCatalog("en", []lxn.Message{
+		{Section: "s", Key: "a"},
+		{Section: "s", Key: "b"},
+		{Section: "s", Key: "a"},
+		{Key: "c"},
+		{Key: "c"},
+		{Section: "s", Key: "a"},
+	})
+
+	var dupErr *DuplicateMessageError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []MessageKey{{"s", "a"}, {"", "c"}}
+	if !reflect.DeepEqual(dupErr.Keys, expected) {
+		t.Errorf("unexpected duplicates: %v", dupErr.Keys)
+	}
+	if msg := `duplicate messages in catalog en: "s"."a", "c"`; err.Error() != msg {
+		t.Errorf("unexpected error message: %q", err.Error())
+	}
+}
+
+func TestMergeCatalogs(t *testing.T) {
+	newCat := mergeTestCatalog(t)
+
+	cat, err := MergeCatalogs(newCat("en", "a0", "b0"), newCat("en", "c1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for key, text := range map[string]string{"a": "a0", "b": "b0", "c": "c1"} {
+		if msg := cat.Message("s", key); msg == nil || msg.Format(newLocale(lxn.Locale{}), nil) != text {
+			t.Errorf("unexpected message %s: %v", key, msg)
+		}
+	}
+
+	_, err = MergeCatalogs(newCat("en", "a0", "b0"), newCat("en", "b1", "c1"), newCat("en", "a2", "b2"))
+	var dupErr *DuplicateMessageError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []MessageKey{{"s", "a"}, {"s", "b"}}; !reflect.DeepEqual(dupErr.Keys, expected) {
+		t.Errorf("unexpected duplicates: %v", dupErr.Keys)
+	}
+
+	if _, err := MergeCatalogs(newCat("en", "a0"), newCat("de", "b0")); err == nil {
+		t.Errorf("expected locale error")
+	}
+}
+
+func TestMergeCatalogsWith(t *testing.T) {
+	newCat := mergeTestCatalog(t)
+
+	catalogs := []*Catalog{
+		newCat("en", "a0", "b0"),
+		newCat("en", "b1", "c1"),
+		newCat("en", "a2", "b2", "d2"),
+	}
+
+	lastWinsForA := func(key MessageKey, prev, next *Message) (*Message, error) {
+		if key.Key == "a" {
+			return next, nil
+		}
+		return prev, nil
+	}
+
+	tests := []struct {
+		resolve   Resolver
+		texts     map[string]string // message key => text
+		overrides []MergeOverride
+	}{
+		{
+			resolve: LastWins,
+			texts:   map[string]string{"a": "a2", "b": "b2", "c": "c1", "d": "d2"},
+			overrides: []MergeOverride{
+				{Key: MessageKey{"s", "a"}, Catalogs: []int{0, 2}, Winner: 2},
+				{Key: MessageKey{"s", "b"}, Catalogs: []int{0, 1, 2}, Winner: 2},
+			},
+		},
+		{
+			resolve: FirstWins,
+			texts:   map[string]string{"a": "a0", "b": "b0", "c": "c1", "d": "d2"},
+			overrides: []MergeOverride{
+				{Key: MessageKey{"s", "a"}, Catalogs: []int{0, 2}, Winner: 0},
+				{Key: MessageKey{"s", "b"}, Catalogs: []int{0, 1, 2}, Winner: 0},
+			},
+		},
+		{
+			resolve: lastWinsForA,
+			texts:   map[string]string{"a": "a2", "b": "b0", "c": "c1", "d": "d2"},
+			overrides: []MergeOverride{
+				{Key: MessageKey{"s", "a"}, Catalogs: []int{0, 2}, Winner: 2},
+				{Key: MessageKey{"s", "b"}, Catalogs: []int{0, 1, 2}, Winner: 0},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		cat, report, err := MergeCatalogsWith(test.resolve, catalogs...)
+		if err != nil {
+			t.Errorf("unexpected error for resolver %d: %v", i, err)
+			continue
+		}
+		for key, text := range test.texts {
+			if msg := cat.Message("s", key); msg == nil || msg.Format(newLocale(lxn.Locale{}), nil) != text {
+				t.Errorf("unexpected message %s for resolver %d: %v", key, i, msg)
+			}
+		}
+		if !reflect.DeepEqual(report.Overrides, test.overrides) {
+			t.Errorf("unexpected overrides for resolver %d: %+v", i, report.Overrides)
+		}
+	}
+
+	if _, _, err := MergeCatalogsWith(ErrorOnConflict, catalogs...); err == nil {
+		t.Errorf("expected conflict error")
+	}
+	if _, _, err := MergeCatalogsWith(ErrorOnConflict, catalogs[0], newCat("en", "c0")); err != nil {
+		t.Errorf("unexpected error without conflicts: %v", err)
+	}
+	if _, _, err := MergeCatalogsWith(LastWins, catalogs[0], newCat("de", "c0")); err == nil {
+		t.Errorf("expected locale error")
+	}
+}
+
+// mergeTestCatalog returns a function which creates a catalog with a message in
+// section "s" for each text. The message key is the first letter of the text.
+func mergeTestCatalog(t *testing.T) func(localeID string, texts ...string) *Catalog {
+	return func(localeID string, texts ...string) *Catalog {
+		msgs := make([]lxn.Message, 0, len(texts))
+		for _, text := range texts {
+			msgs = append(msgs, lxn.Message{Section: "s", Key: text[:1], Text: []string{text}})
+		}
+		cat, err := newCatalog(localeID, msgs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return cat
+	}
+}
@@ -0,0 +1,97 @@
+package lxn
+
+import (
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// formatCompact formats a number in a compact form (e.g. "1.2K") with the given
+// patterns. The plural rules are used to select the pattern for the number's plural
+// form. If there is no pattern for the number's magnitude, the number will be
+// formatted without compacting it.
+//
+// The number is rounded to an integer if it has at least two integer digits after
+// compacting it. Otherwise it is rounded to two significant digits.
+func formatCompact(w *writer, num number, nf *lxn.NumberFormat, patterns []lxn.CompactPattern, plurals []lxn.Plural) {
+	d, ok := toDecimal(num)
+	if !ok {
+		num.format(w, nf, noCurrency)
+		return
+	}
+
+	var (
+		mag      = d.intLen - 1
+		exponent int
+		scaled   Decimal
+		cnf      lxn.NumberFormat
+	)
+	for {
+		exponent = compactPattern(patterns, mag, lxn.Other).Exponent
+		scaled = d.shift(-exponent)
+		cnf = compactNumberFormat(nf, scaled)
+		scaled = scaled.round(cnf.MaxFractionDigits, cnf.RoundingMode)
+
+		// The rounding could carry over into the next magnitude (e.g. 999.95K
+		// becomes 1000K), which might require another pattern.
+		if scaled.intLen+exponent-1 <= mag || compactPattern(patterns, mag+1, lxn.Other).Exponent == exponent {
+			break
+		}
+		mag++
+	}
+
+	full := scaled.shift(exponent)
+	fnf := lxn.NumberFormat{MaxFractionDigits: max(len(full.coeff)-full.intLen, 0)}
+	category := compactPluralTag(full, &fnf, plurals, exponent)
+	pattern := compactPattern(patterns, mag, category)
+
+	prefix, suffix := signAffixes(nf, d.neg && !scaled.IsZero(), scaled.IsZero())
+
+	var buf [maxFloatDigits]rune
+	intDigits, fracDigits := scaled.digits(buf[:], &cnf, rune(nf.Symbols.Zero))
+
+	w.WriteAffix(prefix, &nf.Symbols, noCurrency)
+	w.WriteAffix(pattern.Prefix, &nf.Symbols, noCurrency)
+	w.WriteInt(intDigits, &cnf)
+	w.WriteFrac(fracDigits, &cnf)
+	w.WriteAffix(pattern.Suffix, &nf.Symbols, noCurrency)
+	w.WriteAffix(suffix, &nf.Symbols, noCurrency)
+}
+
+func compactNumberFormat(nf *lxn.NumberFormat, scaled Decimal) lxn.NumberFormat {
+	cnf := *nf
+	cnf.MinFractionDigits = 0
+	cnf.MaxFractionDigits = 0
+	cnf.MinSignificantDigits = 0
+	cnf.MaxSignificantDigits = 0
+	if scaled.round(0, nf.RoundingMode).intLen < 2 {
+		// two significant digits, i.e. one fraction digit for numbers from 1 to 9
+		// and more for numbers below 1 (e.g. 0.012)
+		cnf.MaxFractionDigits = 2 - min(scaled.intLen, 1)
+	}
+	return cnf
+}
+
+// compactPattern returns the pattern for the given magnitude and plural category.
+// The patterns are expected to be sorted by magnitude. If there is no pattern for
+// the category, the pattern for lxn.Other will be used. If there is no pattern at
+// all, an empty pattern with a zero exponent will be returned.
+func compactPattern(patterns []lxn.CompactPattern, mag int, category lxn.PluralCategory) lxn.CompactPattern {
+	// find the largest magnitude not exceeding the given one
+	end := len(patterns)
+	for end > 0 && patterns[end-1].Magnitude > mag {
+		end--
+	}
+	if end == 0 {
+		return lxn.CompactPattern{}
+	}
+
+	var other lxn.CompactPattern
+	for i := end - 1; i >= 0 && patterns[i].Magnitude == patterns[end-1].Magnitude; i-- {
+		switch patterns[i].Category {
+		case category:
+			return patterns[i]
+		case lxn.Other:
+			other = patterns[i]
+		}
+	}
+	return other
+}
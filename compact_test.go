@@ -0,0 +1,148 @@
+package lxn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestFormatCompact(t *testing.T) {
+	nf := lxn.NumberFormat{
+		Symbols: lxn.Symbols{
+			Zero:    '0',
+			Decimal: ".",
+			Group:   ",",
+			Minus:   "-",
+			Nan:     "NaN",
+		},
+		NegativePrefix:           "-",
+		MaxFractionDigits:        3,
+		PrimaryIntegerGrouping:   3,
+		SecondaryIntegerGrouping: 3,
+	}
+
+	patterns := []lxn.CompactPattern{
+		{Magnitude: 3, Category: lxn.Other, Exponent: 3, Suffix: "K"},
+		{Magnitude: 4, Category: lxn.Other, Exponent: 3, Suffix: "K"},
+		{Magnitude: 5, Category: lxn.Other, Exponent: 3, Suffix: "K"},
+		{Magnitude: 6, Category: lxn.Other, Exponent: 6, Suffix: "M"},
+		{Magnitude: 7, Category: lxn.Other, Exponent: 6, Suffix: "M"},
+		{Magnitude: 8, Category: lxn.Other, Exponent: 6, Suffix: "M"},
+	}
+
+	tests := []struct {
+		num      number
+		expected string
+	}{
+		{num: Int(0), expected: "0"},
+		{num: Float(1.234), expected: "1.2"},
+		{num: Float(0.5), expected: "0.5"},
+		{num: Float(0.123), expected: "0.12"},
+		{num: Float(0.0123), expected: "0.012"},
+		{num: Float(-0.0123), expected: "-0.012"},
+		{num: Float(0.000126), expected: "0.00013"},
+		{num: Float(0.0996), expected: "0.1"},
+		{num: Float(0.996), expected: "1"},
+		{num: Float(9.96), expected: "10"},
+		{num: Int(123), expected: "123"},
+		{num: Int(1234), expected: "1.2K"},
+		{num: Int(-1234), expected: "-1.2K"},
+		{num: Uint(12345), expected: "12K"},
+		{num: Float(123456.7), expected: "123K"},
+		{num: Int(999999), expected: "1M"},
+		{num: Int(1500000), expected: "1.5M"},
+		{num: mustParseDecimal("987654321"), expected: "988M"},
+		{num: mustParseDecimal("98765432109876"), expected: "98,765,432M"},
+		{num: Float(math.NaN()), expected: "NaN"},
+	}
+
+	for _, test := range tests {
+		var w writer
+		formatCompact(&w, test.num, &nf, patterns, nil)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected compact format for %q: %s", test.expected, s)
+		}
+	}
+}
+
+func TestFormatCompactWithPlurals(t *testing.T) {
+	nf := lxn.NumberFormat{
+		Symbols: lxn.Symbols{
+			Zero:    '0',
+			Decimal: ",",
+		},
+		MaxFractionDigits: 3,
+	}
+
+	// French: many -> e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5
+	plurals := []lxn.Plural{
+		{
+			Category: lxn.One,
+			Rules: []lxn.PluralRule{
+				{Operand: lxn.IntegerDigits, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 1}}},
+			},
+		},
+		{
+			Category: lxn.Many,
+			Rules: []lxn.PluralRule{
+				{Operand: lxn.CompactDecExponent, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+				{Operand: lxn.IntegerDigits, Negate: true, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+				{Operand: lxn.IntegerDigits, Modulo: 1000000, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+				{Operand: lxn.NumFracDigits, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Disjunction},
+				{Operand: lxn.CompactDecExponent, Negate: true, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 5}}},
+			},
+		},
+	}
+
+	patterns := []lxn.CompactPattern{
+		{Magnitude: 3, Category: lxn.Other, Exponent: 3, Suffix: " mille"},
+		{Magnitude: 6, Category: lxn.One, Exponent: 6, Suffix: " million"},
+		{Magnitude: 6, Category: lxn.Many, Exponent: 6, Suffix: " de millions"},
+		{Magnitude: 6, Category: lxn.Other, Exponent: 6, Suffix: " millions"},
+	}
+
+	tests := []struct {
+		num      number
+		expected string
+	}{
+		{num: Int(1200), expected: "1,2 mille"},
+		{num: Int(1200000), expected: "1,2 de millions"},
+		{num: Int(2000000), expected: "2 de millions"},
+	}
+
+	for _, test := range tests {
+		var w writer
+		formatCompact(&w, test.num, &nf, patterns, plurals)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected compact format for %q: %s", test.expected, s)
+		}
+	}
+}
+
+func TestCompactPattern(t *testing.T) {
+	patterns := []lxn.CompactPattern{
+		{Magnitude: 3, Category: lxn.One, Exponent: 3, Suffix: "one"},
+		{Magnitude: 3, Category: lxn.Other, Exponent: 3, Suffix: "other"},
+		{Magnitude: 6, Category: lxn.Other, Exponent: 6, Suffix: "M"},
+	}
+
+	tests := []struct {
+		mag      int
+		category lxn.PluralCategory
+		expected string
+	}{
+		{mag: 2, category: lxn.Other, expected: ""},
+		{mag: 3, category: lxn.One, expected: "one"},
+		{mag: 3, category: lxn.Few, expected: "other"},
+		{mag: 5, category: lxn.Other, expected: "other"},
+		{mag: 9, category: lxn.One, expected: "M"},
+	}
+
+	for _, test := range tests {
+		p := compactPattern(patterns, test.mag, test.category)
+		if p.Suffix != test.expected {
+			t.Errorf("unexpected compact pattern for magnitude %d: %q", test.mag, p.Suffix)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package lxn
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// isoCurrencies holds the ISO 4217 currencies whose fraction digits differ from
+// the default of two digits, according to the CLDR currency data. None of them
+// requires a rounding increment.
+var isoCurrencies = map[string]lxn.Currency{
+	"ADP": {FractionDigits: 0},
+	"AFN": {FractionDigits: 0},
+	"ALL": {FractionDigits: 0},
+	"BHD": {FractionDigits: 3},
+	"BIF": {FractionDigits: 0},
+	"BYR": {FractionDigits: 0},
+	"CLF": {FractionDigits: 4},
+	"CLP": {FractionDigits: 0},
+	"DJF": {FractionDigits: 0},
+	"ESP": {FractionDigits: 0},
+	"GNF": {FractionDigits: 0},
+	"IQD": {FractionDigits: 0},
+	"IRR": {FractionDigits: 0},
+	"ISK": {FractionDigits: 0},
+	"ITL": {FractionDigits: 0},
+	"JOD": {FractionDigits: 3},
+	"JPY": {FractionDigits: 0},
+	"KMF": {FractionDigits: 0},
+	"KPW": {FractionDigits: 0},
+	"KRW": {FractionDigits: 0},
+	"KWD": {FractionDigits: 3},
+	"LAK": {FractionDigits: 0},
+	"LBP": {FractionDigits: 0},
+	"LUF": {FractionDigits: 0},
+	"LYD": {FractionDigits: 3},
+	"MGA": {FractionDigits: 0},
+	"MGF": {FractionDigits: 0},
+	"MMK": {FractionDigits: 0},
+	"MRO": {FractionDigits: 0},
+	"OMR": {FractionDigits: 3},
+	"PYG": {FractionDigits: 0},
+	"RSD": {FractionDigits: 0},
+	"RWF": {FractionDigits: 0},
+	"SLL": {FractionDigits: 0},
+	"SOS": {FractionDigits: 0},
+	"STD": {FractionDigits: 0},
+	"SYP": {FractionDigits: 0},
+	"TMM": {FractionDigits: 0},
+	"TND": {FractionDigits: 3},
+	"TRL": {FractionDigits: 0},
+	"UGX": {FractionDigits: 0},
+	"UYI": {FractionDigits: 0},
+	"UYW": {FractionDigits: 4},
+	"VND": {FractionDigits: 0},
+	"VUV": {FractionDigits: 0},
+	"XAF": {FractionDigits: 0},
+	"XOF": {FractionDigits: 0},
+	"XPF": {FractionDigits: 0},
+	"YER": {FractionDigits: 0},
+	"ZMK": {FractionDigits: 0},
+	"ZWD": {FractionDigits: 0},
+}
+
+// isoCurrency returns the locale-independent fraction data of the currency with
+// the given ISO 4217 code. The currency has no symbols.
+func isoCurrency(code string) lxn.Currency {
+	if curr, has := isoCurrencies[code]; has {
+		return curr
+	}
+	return lxn.Currency{FractionDigits: 2}
+}
+
+// moneyFormat returns the number format, the currency symbol, and the number
+// for the given ISO 4217 currency code. If the locale does not know the currency,
+// the fraction data of the ISO 4217 currency will be used and the code serves as
+// the currency symbol. The accounting style falls back to the money format if the
+// locale has no accounting format. The skeleton is applied to the number format
+// before the number is rounded to the currency's rounding increment.
+func moneyFormat(num number, loc *lxn.Locale, code string, display lxn.CurrencyDisplay, style lxn.CurrencyStyle, sk skeleton) (lxn.NumberFormat, string, number) {
+	nf := loc.MoneyFormat
+	if style == lxn.CurrencyAccounting && loc.AccountingFormat.Symbols.Zero != 0 {
+		nf = loc.AccountingFormat
+	}
+
+	curr, has := loc.Currencies[code]
+	if !has {
+		curr = isoCurrency(code)
+	}
+
+	nf.MinFractionDigits = curr.FractionDigits
+	nf.MaxFractionDigits = curr.FractionDigits
+	sk.apply(&nf)
+	num = roundToIncrement(num, curr.FractionDigits, curr.RoundingIncrement, nf.RoundingMode)
+	return nf, currencySymbol(&curr, code, display), num
+}
+
+func currencySymbol(curr *lxn.Currency, code string, display lxn.CurrencyDisplay) string {
+	switch {
+	case display == lxn.CurrencyNarrowSymbol && curr.NarrowSymbol != "":
+		return curr.NarrowSymbol
+	case display != lxn.CurrencyCode && curr.Symbol != "":
+		return curr.Symbol
+	default:
+		return code
+	}
+}
+
+// roundToIncrement rounds the number to a multiple of the increment using the
+// rounding mode, where the increment is given in units of the last fraction digit.
+// Integers are kept if the result is still an integer, otherwise a decimal number
+// is returned.
+func roundToIncrement(num number, fracDigits int, increment int, mode lxn.RoundingMode) number {
+	if increment <= 1 {
+		return num
+	}
+	d, ok := toDecimal(num)
+	if !ok {
+		return num
+	}
+
+	// The number is divided by the increment and the quotient is rounded to an
+	// integer. Since the quotient's fraction may not be finite, only its first
+	// digit is kept, followed by a single non-zero digit if there are more
+	// digits. This is sufficient for all rounding modes.
+	scale := max(len(d.coeff)-d.intLen, 0)
+	div := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	div.Mul(div, big.NewInt(int64(increment)))
+	val := d.shift(fracDigits).unscaled(scale)
+	q, r := val.QuoRem(val.Abs(val), div, new(big.Int))
+
+	digits := q.Append(nil, 10)
+	intLen := len(digits)
+	first, rest := r.QuoRem(r.Mul(r, big.NewInt(10)), div, new(big.Int))
+	digits = append(digits, byte('0'+first.Int64()))
+	if rest.Sign() != 0 {
+		digits = append(digits, '1')
+	}
+	quo := newDecimal(d.neg, digits, intLen).round(0, mode).unscaled(0)
+	res, err := NewDecimal(quo.Mul(quo, big.NewInt(int64(increment))), fracDigits)
+	if err != nil {
+		return num
+	}
+
+	switch num.(type) {
+	case Int:
+		if i := res.unscaled(0); res.intLen >= len(res.coeff) && i.IsInt64() {
+			return Int(i.Int64())
+		}
+	case Uint:
+		if i := res.unscaled(0); res.intLen >= len(res.coeff) && i.IsUint64() {
+			return Uint(i.Uint64())
+		}
+	case Float:
+		f, _ := strconv.ParseFloat(res.String(), 64)
+		return Float(f)
+	}
+	return res
+}
@@ -0,0 +1,188 @@
+package lxn
+
+import (
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestMoneyFormat(t *testing.T) {
+	loc := lxn.Locale{
+		MoneyFormat: lxn.NumberFormat{
+			Symbols: lxn.Symbols{
+				Zero:    '0',
+				Decimal: ".",
+				Group:   ",",
+			},
+			PositivePrefix:           string(currencyPlaceholder),
+			MinFractionDigits:        2,
+			MaxFractionDigits:        2,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		AccountingFormat: lxn.NumberFormat{
+			Symbols: lxn.Symbols{
+				Zero:    '0',
+				Decimal: ".",
+				Group:   ",",
+			},
+			PositivePrefix:           string(currencyPlaceholder),
+			NegativePrefix:           "(" + string(currencyPlaceholder),
+			NegativeSuffix:           ")",
+			MinFractionDigits:        2,
+			MaxFractionDigits:        2,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		Currencies: map[string]lxn.Currency{
+			"USD": {Symbol: "US$", NarrowSymbol: "$", FractionDigits: 2},
+			"JPY": {Symbol: "¥", FractionDigits: 0},
+			"CHF": {FractionDigits: 2, RoundingIncrement: 5},
+		},
+	}
+
+	tests := []struct {
+		num      number
+		currency string
+		display  lxn.CurrencyDisplay
+		style    lxn.CurrencyStyle
+		skeleton skeleton
+		expected string
+	}{
+		{
+			num:      Float(1234),
+			currency: "USD",
+			display:  lxn.CurrencySymbol,
+			expected: "US$1,234.00",
+		},
+		{
+			num:      Float(1234),
+			currency: "USD",
+			display:  lxn.CurrencyNarrowSymbol,
+			expected: "$1,234.00",
+		},
+		{
+			num:      Float(1234),
+			currency: "USD",
+			display:  lxn.CurrencyCode,
+			expected: "USD1,234.00",
+		},
+		{
+			num:      Float(1234.4),
+			currency: "JPY",
+			display:  lxn.CurrencyNarrowSymbol,
+			expected: "¥1,234",
+		},
+		{
+			num:      Int(1234),
+			currency: "JPY",
+			expected: "¥1,234",
+		},
+		{
+			num:      Float(1.23),
+			currency: "CHF",
+			expected: "CHF1.25",
+		},
+		{
+			num:      Float(1.27),
+			currency: "CHF",
+			skeleton: skeleton{func(nf *lxn.NumberFormat) { nf.RoundingMode = lxn.RoundDown }},
+			expected: "CHF1.25",
+		},
+		{
+			num:      Float(1.21),
+			currency: "CHF",
+			skeleton: skeleton{func(nf *lxn.NumberFormat) { nf.RoundingMode = lxn.RoundUp }},
+			expected: "CHF1.25",
+		},
+		{
+			num:      Float(1.5),
+			currency: "EUR",
+			expected: "EUR1.50",
+		},
+		{
+			num:      Float(1234.4),
+			currency: "KRW",
+			expected: "KRW1,234",
+		},
+		{
+			num:      Float(1.5),
+			currency: "KWD",
+			expected: "KWD1.500",
+		},
+		{
+			num:      Float(1.5),
+			currency: "XYZ",
+			expected: "XYZ1.50",
+		},
+		{
+			num:      Float(-1234),
+			currency: "USD",
+			display:  lxn.CurrencyNarrowSymbol,
+			style:    lxn.CurrencyAccounting,
+			expected: "($1,234.00)",
+		},
+		{
+			num:      Float(1234),
+			currency: "USD",
+			display:  lxn.CurrencyNarrowSymbol,
+			style:    lxn.CurrencyAccounting,
+			expected: "$1,234.00",
+		},
+	}
+
+	for _, test := range tests {
+		nf, symbol, num := moneyFormat(test.num, &loc, test.currency, test.display, test.style, test.skeleton)
+
+		var w writer
+		num.format(&w, &nf, symbol)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected money format for %q: %s", test.expected, s)
+		}
+	}
+}
+
+func TestRoundToIncrement(t *testing.T) {
+	tests := []struct {
+		num        number
+		fracDigits int
+		increment  int
+		mode       lxn.RoundingMode
+		expected   number
+	}{
+		{num: Float(1.23), fracDigits: 2, increment: 0, expected: Float(1.23)},
+		{num: Float(1.23), fracDigits: 2, increment: 5, expected: Float(1.25)},
+		{num: Float(1.22), fracDigits: 2, increment: 5, expected: Float(1.2)},
+		{num: Float(-1.23), fracDigits: 2, increment: 5, expected: Float(-1.25)},
+		{num: Float(1.225), fracDigits: 2, increment: 5, expected: Float(1.2)},
+		{num: Float(1.275), fracDigits: 2, increment: 5, expected: Float(1.3)},
+		{num: Float(1.21), fracDigits: 2, increment: 5, mode: lxn.RoundUp, expected: Float(1.25)},
+		{num: Float(1.24), fracDigits: 2, increment: 5, mode: lxn.RoundDown, expected: Float(1.2)},
+		{num: Float(-1.21), fracDigits: 2, increment: 5, mode: lxn.RoundCeiling, expected: Float(-1.2)},
+		{num: Float(1.225), fracDigits: 2, increment: 5, mode: lxn.RoundHalfUp, expected: Float(1.25)},
+		{num: Float(1.225), fracDigits: 2, increment: 5, mode: lxn.RoundHalfDown, expected: Float(1.2)},
+		{num: Float(1.2251), fracDigits: 2, increment: 5, mode: lxn.RoundHalfDown, expected: Float(1.25)},
+		{num: Int(12), fracDigits: 0, increment: 5, expected: Int(10)},
+		{num: Int(-13), fracDigits: 0, increment: 5, expected: Int(-15)},
+		{num: Int(-12), fracDigits: 0, increment: 5, mode: lxn.RoundFloor, expected: Int(-15)},
+		{num: Uint(13), fracDigits: 0, increment: 5, expected: Uint(15)},
+		{num: Uint(11), fracDigits: 0, increment: 5, mode: lxn.RoundUp, expected: Uint(15)},
+		{num: Uint(13), fracDigits: 2, increment: 5, expected: Uint(13)},
+		{num: Uint(13), fracDigits: 1, increment: 3, expected: mustParseDecimal("12.9")},
+		{num: mustParseDecimal("1.23"), fracDigits: 2, increment: 5, expected: mustParseDecimal("1.25")},
+		{num: mustParseDecimal("-1.224"), fracDigits: 2, increment: 5, expected: mustParseDecimal("-1.2")},
+		{num: mustParseDecimal("1.2000001"), fracDigits: 2, increment: 5, mode: lxn.RoundUp, expected: mustParseDecimal("1.25")},
+	}
+
+	for _, test := range tests {
+		got := roundToIncrement(test.num, test.fracDigits, test.increment, test.mode)
+		if f, ok := got.(Float); ok {
+			diff := float64(f) - float64(test.expected.(Float))
+			if diff < -1e-9 || diff > 1e-9 {
+				t.Errorf("unexpected rounding for %v: %v", test.num, got)
+			}
+		} else if got != test.expected {
+			t.Errorf("unexpected rounding for %v: %v", test.num, got)
+		}
+	}
+}
@@ -0,0 +1,583 @@
+package lxn
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// Time is a date and time variable which can be passed to message replacements.
+// The date and time is formatted in the time's location, i.e. the caller
+// needs to convert the time into the user's time zone beforehand.
+//
+// A skeleton is matched against the locale's available formats when the time
+// is formatted. Missing fields are not appended to a matching format, i.e. if the
+// locale has no format with the skeleton's fields (or separate formats for its
+// date and time fields), the replacement is reported as corrupted.
+type Time time.Time
+
+// String implements the Variable interface.
+func (t Time) String() string {
+	return time.Time(t).Format(time.RFC3339)
+}
+
+func (t Time) format(w *writer, pattern string, cal *lxn.Calendar, zero rune) {
+	tm := time.Time(t)
+	for pattern != "" {
+		ch, n := utf8.DecodeRuneInString(pattern)
+		switch {
+		case ch == '\'':
+			pattern = writeQuoted(w, pattern[n:])
+
+		case isPatternLetter(ch):
+			count := 1
+			for count < len(pattern) && rune(pattern[count]) == ch {
+				count++
+			}
+			writeTimeField(w, tm, byte(ch), count, cal, zero)
+			pattern = pattern[count:]
+
+		default:
+			w.WriteRune(ch)
+			pattern = pattern[n:]
+		}
+	}
+}
+
+// writeQuoted writes the quoted literal at the beginning of the pattern (without
+// the opening quote) and returns the remaining pattern. Two consecutive quotes
+// represent a literal quote.
+func writeQuoted(w *writer, pattern string) string {
+	if strings.HasPrefix(pattern, "'") {
+		w.WriteByte('\'')
+		return pattern[1:]
+	}
+
+	for {
+		idx := strings.IndexByte(pattern, '\'')
+		if idx < 0 {
+			w.WriteString(pattern)
+			return ""
+		}
+		w.WriteString(pattern[:idx])
+		pattern = pattern[idx+1:]
+		if !strings.HasPrefix(pattern, "'") {
+			return pattern
+		}
+		w.WriteByte('\'')
+		pattern = pattern[1:]
+	}
+}
+
+func isPatternLetter(ch rune) bool {
+	return ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z')
+}
+
+func writeTimeField(w *writer, tm time.Time, field byte, count int, cal *lxn.Calendar, zero rune) {
+	switch field {
+	case 'G': // era
+		era := 1
+		if tm.Year() <= 0 {
+			era = 0
+		}
+		writeCalendarName(w, &cal.Eras, count, era, zero)
+
+	case 'y', 'Y', 'u': // year
+		year := tm.Year()
+		switch {
+		case field == 'Y':
+			// The locale data does not contain any week data, so the week-based
+			// year follows ISO 8601.
+			year, _ = tm.ISOWeek()
+		case field == 'y' && year <= 0:
+			year = 1 - year // year of era
+		}
+		if count == 2 {
+			writeTimeNumber(w, year%100, 2, zero)
+		} else {
+			writeTimeNumber(w, year, count, zero)
+		}
+
+	case 'Q', 'q': // quarter
+		writeTimeNumber(w, (int(tm.Month())-1)/3+1, count, zero)
+
+	case 'M', 'L': // month
+		if count <= 2 {
+			writeTimeNumber(w, int(tm.Month()), count, zero)
+		} else {
+			writeCalendarName(w, &cal.Months, count, int(tm.Month())-1, zero)
+		}
+
+	case 'd': // day of month
+		writeTimeNumber(w, tm.Day(), count, zero)
+
+	case 'D': // day of year
+		writeTimeNumber(w, tm.YearDay(), count, zero)
+
+	case 'F': // day of week in month
+		writeTimeNumber(w, (tm.Day()-1)/7+1, count, zero)
+
+	case 'E': // day of week
+		writeCalendarName(w, &cal.Weekdays, max(count, 3), int(tm.Weekday()), zero)
+
+	case 'e', 'c': // local day of week
+		if count <= 2 {
+			writeTimeNumber(w, int(tm.Weekday())+1, count, zero)
+		} else {
+			writeCalendarName(w, &cal.Weekdays, count, int(tm.Weekday()), zero)
+		}
+
+	case 'a', 'b', 'B': // period
+		period := 0
+		if tm.Hour() >= 12 {
+			period = 1
+		}
+		writeCalendarName(w, &cal.DayPeriods, max(count, 3), period, zero)
+
+	case 'h': // hour [1-12]
+		h := tm.Hour() % 12
+		if h == 0 {
+			h = 12
+		}
+		writeTimeNumber(w, h, count, zero)
+
+	case 'H': // hour [0-23]
+		writeTimeNumber(w, tm.Hour(), count, zero)
+
+	case 'K': // hour [0-11]
+		writeTimeNumber(w, tm.Hour()%12, count, zero)
+
+	case 'k': // hour [1-24]
+		h := tm.Hour()
+		if h == 0 {
+			h = 24
+		}
+		writeTimeNumber(w, h, count, zero)
+
+	case 'm': // minute
+		writeTimeNumber(w, tm.Minute(), count, zero)
+
+	case 's': // second
+		writeTimeNumber(w, tm.Second(), count, zero)
+
+	case 'S': // fractional second
+		const nanoDigits = 9
+		frac := tm.Nanosecond()
+		for i := count; i < nanoDigits; i++ {
+			frac /= 10
+		}
+		writeTimeNumber(w, frac, min(count, nanoDigits), zero)
+		for i := nanoDigits; i < count; i++ {
+			w.WriteRune(zero)
+		}
+
+	case 'A': // milliseconds in day
+		h, m, s := tm.Clock()
+		ms := ((h*60+m)*60+s)*1000 + tm.Nanosecond()/int(time.Millisecond)
+		writeTimeNumber(w, ms, count, zero)
+
+	case 'z', 'v', 'V': // time zone name
+		name, _ := tm.Zone()
+		w.WriteString(name)
+
+	case 'Z', 'O', 'x', 'X': // time zone offset
+		_, offset := tm.Zone()
+		writeZoneOffset(w, field, count, offset, zero)
+
+	default:
+		// Unsupported pattern letters are rejected by timePattern, so this
+		// can only happen for patterns which were not checked.
+	}
+}
+
+func writeZoneOffset(w *writer, field byte, count int, offset int, zero rune) {
+	switch {
+	case field == 'O' || (field == 'Z' && count == 4):
+		w.WriteString("GMT")
+		if offset == 0 {
+			return
+		}
+	case field == 'X' || (field == 'Z' && count == 5):
+		if offset == 0 {
+			w.WriteByte('Z')
+			return
+		}
+	}
+
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	hours, minutes := offset/3600, (offset/60)%60
+
+	w.WriteByte(sign)
+	switch {
+	case field == 'O' && count < 4:
+		writeTimeNumber(w, hours, 1, zero)
+		if minutes != 0 {
+			w.WriteByte(':')
+			writeTimeNumber(w, minutes, 2, zero)
+		}
+	case (field == 'x' || field == 'X') && count == 1:
+		writeTimeNumber(w, hours, 2, zero)
+		if minutes != 0 {
+			writeTimeNumber(w, minutes, 2, zero)
+		}
+	case field == 'Z' && count < 4, (field == 'x' || field == 'X') && (count == 2 || count == 4):
+		writeTimeNumber(w, hours, 2, zero)
+		writeTimeNumber(w, minutes, 2, zero)
+	default:
+		writeTimeNumber(w, hours, 2, zero)
+		w.WriteByte(':')
+		writeTimeNumber(w, minutes, 2, zero)
+	}
+}
+
+func writeTimeNumber(w *writer, n int, minDigits int, zero rune) {
+	if n < 0 {
+		w.WriteByte('-')
+		n = -n
+	}
+
+	var buf [maxIntDigits]rune
+	nf := lxn.NumberFormat{MinIntegerDigits: min(minDigits, maxIntDigits/2)}
+	intDigits, _ := Uint(n).digits(buf[:], &nf, zero)
+	w.WriteRunes(intDigits)
+}
+
+// writeCalendarName writes the name with the given index. The pattern count defines
+// the width of the name: abbreviated (up to three letters), wide (four letters),
+// or narrow (five letters). If there is no name available, the numeric value
+// (index + 1) will be written.
+func writeCalendarName(w *writer, names *lxn.CalendarNames, count int, idx int, zero rune) {
+	var list []string
+	switch count {
+	case 4:
+		list = names.Wide
+	case 5:
+		list = names.Narrow
+	default:
+		list = names.Abbreviated
+	}
+
+	if idx < len(list) && list[idx] != "" {
+		w.WriteString(list[idx])
+	} else {
+		writeTimeNumber(w, idx+1, 1, zero)
+	}
+}
+
+// timePattern returns the date/time pattern for the given replacement details. If
+// neither a skeleton nor a style is given, the medium date and time format will be
+// used. An error is returned if the pattern contains unsupported fields or if
+// there is no pattern for the skeleton.
+func timePattern(details *lxn.TimeDetails, cal *lxn.Calendar) (string, error) {
+	var pattern string
+	if details.Skeleton != "" {
+		var err error
+		if pattern, err = skeletonPattern(details.Skeleton, cal); err != nil {
+			return "", err
+		}
+	} else {
+		pattern = stylesPattern(details.DateStyle, details.TimeStyle, cal)
+	}
+
+	if err := checkTimePattern(pattern); err != nil {
+		return "", err
+	}
+	return pattern, nil
+}
+
+func stylesPattern(dateStyle lxn.DateTimeStyle, timeStyle lxn.DateTimeStyle, cal *lxn.Calendar) string {
+	if dateStyle == lxn.DateTimeNone && timeStyle == lxn.DateTimeNone {
+		dateStyle, timeStyle = lxn.DateTimeMedium, lxn.DateTimeMedium
+	}
+
+	switch {
+	case timeStyle == lxn.DateTimeNone:
+		return stylePattern(&cal.DateFormats, dateStyle)
+	case dateStyle == lxn.DateTimeNone:
+		return stylePattern(&cal.TimeFormats, timeStyle)
+	}
+
+	date := stylePattern(&cal.DateFormats, dateStyle)
+	time := stylePattern(&cal.TimeFormats, timeStyle)
+	return joinDateTime(date, time, stylePattern(&cal.DateTimeFormats, dateStyle))
+}
+
+func joinDateTime(date string, time string, glue string) string {
+	if glue == "" {
+		glue = "{1} {0}"
+	}
+	return strings.NewReplacer("{1}", date, "{0}", time).Replace(glue)
+}
+
+func stylePattern(patterns *lxn.DateTimePatterns, style lxn.DateTimeStyle) string {
+	switch style {
+	case lxn.DateTimeShort:
+		return patterns.Short
+	case lxn.DateTimeLong:
+		return patterns.Long
+	case lxn.DateTimeFull:
+		return patterns.Full
+	default:
+		return patterns.Medium
+	}
+}
+
+// timeField is a single field of a date/time skeleton, e.g. "MMM" for the
+// abbreviated month.
+type timeField struct {
+	letter byte
+	count  int
+}
+
+// timeFieldClass returns the class of a pattern letter. Letters of the same class
+// describe the same field, e.g. 'M' and 'L' both describe the month. Zero is
+// returned for unsupported letters. The letter 'j' (the locale's preferred hour
+// format) is only valid in skeletons.
+func timeFieldClass(letter byte) byte {
+	switch letter {
+	case 'G', 'y', 'Y', 'Q', 'M', 'd', 'D', 'F', 'E', 'a', 'H', 'm', 's', 'S', 'A', 'z':
+		return letter
+	case 'u':
+		return 'y'
+	case 'q':
+		return 'Q'
+	case 'L':
+		return 'M'
+	case 'e', 'c':
+		return 'E'
+	case 'b', 'B':
+		return 'a'
+	case 'h', 'K', 'k', 'j':
+		return 'H'
+	case 'v', 'V', 'Z', 'O', 'x', 'X':
+		return 'z'
+	default:
+		return 0
+	}
+}
+
+func isDateField(class byte) bool {
+	return strings.IndexByte("GyYQMdDFE", class) >= 0
+}
+
+// parseTimeSkeleton splits a date/time skeleton (e.g. "yMMMd") into its fields.
+// Each field class must occur at most once.
+func parseTimeSkeleton(skeleton string) ([]timeField, error) {
+	fields := make([]timeField, 0, len(skeleton))
+	for i := 0; i < len(skeleton); {
+		letter := skeleton[i]
+		class := timeFieldClass(letter)
+		if class == 0 {
+			return nil, fmt.Errorf("unsupported field in date/time skeleton %q", skeleton)
+		}
+		if _, has := findTimeField(fields, class); has {
+			return nil, fmt.Errorf("duplicate field in date/time skeleton %q", skeleton)
+		}
+
+		count := 1
+		for i+count < len(skeleton) && skeleton[i+count] == letter {
+			count++
+		}
+		fields = append(fields, timeField{letter: letter, count: count})
+		i += count
+	}
+	return fields, nil
+}
+
+func findTimeField(fields []timeField, class byte) (timeField, bool) {
+	for _, f := range fields {
+		if timeFieldClass(f.letter) == class {
+			return f, true
+		}
+	}
+	return timeField{}, false
+}
+
+// checkTimePattern checks whether all fields of a date/time pattern are supported.
+func checkTimePattern(pattern string) error {
+	quoted := false
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case ch == '\'':
+			quoted = !quoted
+		case !quoted && isPatternLetter(rune(ch)) && (ch == 'j' || timeFieldClass(ch) == 0):
+			return fmt.Errorf("unsupported field %q in date/time pattern %q", ch, pattern)
+		}
+	}
+	return nil
+}
+
+// skeletonPattern returns the pattern which matches the skeleton best, following
+// the CLDR matching algorithm: the available format with the same fields and the
+// smallest difference in the field widths is chosen, and the widths of its fields
+// are adjusted to the requested ones. If there is no such format, the skeleton is
+// split into a date and a time part, which are matched separately and joined with
+// the date/time format.
+//
+// Unlike CLDR, fields which are missing in all available formats are not appended
+// (appendItems), e.g. "Hms" does not match if only "Hm" and "ms" are available.
+//
+// https://unicode.org/reports/tr35/tr35-dates.html#Matching_Skeletons
+func skeletonPattern(skeleton string, cal *lxn.Calendar) (string, error) {
+	if strings.IndexByte(skeleton, 'j') >= 0 {
+		skeleton = strings.ReplaceAll(skeleton, "j", string(preferredHour(cal)))
+	}
+	if pattern, has := cal.AvailableFormats[skeleton]; has {
+		return pattern, nil
+	}
+
+	fields, err := parseTimeSkeleton(skeleton)
+	if err != nil {
+		return "", err
+	}
+	if pattern, ok := matchTimeSkeleton(fields, cal.AvailableFormats); ok {
+		return pattern, nil
+	}
+
+	var dateFields, timeFields []timeField
+	for _, f := range fields {
+		if isDateField(timeFieldClass(f.letter)) {
+			dateFields = append(dateFields, f)
+		} else {
+			timeFields = append(timeFields, f)
+		}
+	}
+	if len(dateFields) != 0 && len(timeFields) != 0 {
+		date, dateOK := matchTimeSkeleton(dateFields, cal.AvailableFormats)
+		time, timeOK := matchTimeSkeleton(timeFields, cal.AvailableFormats)
+		if dateOK && timeOK {
+			return joinDateTime(date, time, stylePattern(&cal.DateTimeFormats, dateTimeStyle(dateFields))), nil
+		}
+	}
+	return "", fmt.Errorf("no date/time pattern for skeleton %q", skeleton)
+}
+
+// preferredHour returns the hour letter of the locale's short time format, which
+// replaces the letter 'j' in skeletons.
+func preferredHour(cal *lxn.Calendar) byte {
+	pattern := cal.TimeFormats.Short
+	for i := 0; i < len(pattern); i++ {
+		if ch := pattern[i]; ch != 'j' && timeFieldClass(ch) == 'H' {
+			return ch
+		}
+	}
+	return 'H'
+}
+
+// dateTimeStyle returns the style of the date/time format which is used to join
+// the patterns for the given date fields and some time fields.
+func dateTimeStyle(dateFields []timeField) lxn.DateTimeStyle {
+	month, _ := findTimeField(dateFields, 'M')
+	_, hasWeekday := findTimeField(dateFields, 'E')
+	switch {
+	case month.count >= 4 && hasWeekday:
+		return lxn.DateTimeFull
+	case month.count >= 4:
+		return lxn.DateTimeLong
+	case month.count == 3:
+		return lxn.DateTimeMedium
+	default:
+		return lxn.DateTimeShort
+	}
+}
+
+// matchTimeSkeleton returns the pattern of the available format which matches the
+// requested fields best. The day period is not considered, since it belongs to the
+// hour format of the pattern.
+func matchTimeSkeleton(requested []timeField, formats map[string]string) (string, bool) {
+	var (
+		bestSkeleton string
+		bestFields   []timeField
+		bestDist     = -1
+	)
+	for skeleton := range formats {
+		fields, err := parseTimeSkeleton(skeleton)
+		if err != nil {
+			continue
+		}
+		dist, ok := timeSkeletonDistance(requested, fields)
+		if ok && (bestDist < 0 || dist < bestDist || (dist == bestDist && skeleton < bestSkeleton)) {
+			bestSkeleton, bestFields, bestDist = skeleton, fields, dist
+		}
+	}
+	if bestDist < 0 {
+		return "", false
+	}
+	return adjustTimeFields(formats[bestSkeleton], requested, bestFields), true
+}
+
+// timeSkeletonDistance returns the distance between the requested fields and the
+// fields of an available format. A different letter for the same field weighs
+// more than a numeric field instead of a textual one (or vice versa), which weighs
+// more than a different width. If the fields differ, false is returned.
+func timeSkeletonDistance(requested []timeField, available []timeField) (int, bool) {
+	dist, n := 0, 0
+	for _, f := range available {
+		class := timeFieldClass(f.letter)
+		if class == 'a' {
+			continue
+		}
+		r, has := findTimeField(requested, class)
+		if !has {
+			return 0, false
+		}
+
+		switch {
+		case r.letter != f.letter:
+			dist += 0x100
+		case (r.count >= 3) != (f.count >= 3):
+			dist += 0x10
+		}
+		dist += max(r.count-f.count, f.count-r.count)
+		n++
+	}
+
+	for _, r := range requested {
+		if timeFieldClass(r.letter) != 'a' {
+			n--
+		}
+	}
+	return dist, n == 0
+}
+
+// adjustTimeFields adjusts the widths of the pattern's fields to the requested
+// widths if they differ from the available format. The widths of hours, minutes,
+// and seconds are kept, since they are chosen by the locale.
+func adjustTimeFields(pattern string, requested []timeField, available []timeField) string {
+	var sb strings.Builder
+	sb.Grow(len(pattern))
+	quoted := false
+	for i := 0; i < len(pattern); {
+		ch := pattern[i]
+		if ch == '\'' || quoted || !isPatternLetter(rune(ch)) {
+			if ch == '\'' {
+				quoted = !quoted
+			}
+			sb.WriteByte(ch)
+			i++
+			continue
+		}
+
+		count := 1
+		for i+count < len(pattern) && pattern[i+count] == ch {
+			count++
+		}
+		i += count
+
+		class := timeFieldClass(ch)
+		r, _ := findTimeField(requested, class)
+		f, _ := findTimeField(available, class)
+		if r.count != f.count && strings.IndexByte("aHms", class) < 0 {
+			count = r.count
+		}
+		sb.WriteString(strings.Repeat(string(ch), count))
+	}
+	return sb.String()
+}
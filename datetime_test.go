@@ -0,0 +1,238 @@
+package lxn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+var testCalendar = lxn.Calendar{
+	Months: lxn.CalendarNames{
+		Abbreviated: []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Wide:        []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		Narrow:      []string{"J", "F", "M", "A", "M", "J", "J", "A", "S", "O", "N", "D"},
+	},
+	Weekdays: lxn.CalendarNames{
+		Abbreviated: []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		Wide:        []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		Narrow:      []string{"S", "M", "T", "W", "T", "F", "S"},
+	},
+	DayPeriods: lxn.CalendarNames{
+		Abbreviated: []string{"AM", "PM"},
+	},
+	Eras: lxn.CalendarNames{
+		Abbreviated: []string{"BC", "AD"},
+		Wide:        []string{"Before Christ", "Anno Domini"},
+	},
+	DateFormats: lxn.DateTimePatterns{
+		Full:   "EEEE, MMMM d, y",
+		Long:   "MMMM d, y",
+		Medium: "MMM d, y",
+		Short:  "M/d/yy",
+	},
+	TimeFormats: lxn.DateTimePatterns{
+		Full:   "h:mm:ss a zzzz",
+		Long:   "h:mm:ss a z",
+		Medium: "h:mm:ss a",
+		Short:  "h:mm a",
+	},
+	DateTimeFormats: lxn.DateTimePatterns{
+		Full:   "{1} 'at' {0}",
+		Long:   "{1} 'at' {0}",
+		Medium: "{1}, {0}",
+		Short:  "{1}, {0}",
+	},
+	AvailableFormats: map[string]string{
+		"yMMMd": "MMM d, y",
+		"yMd":   "M/d/y",
+		"MMMEd": "E, MMM d",
+		"Hm":    "HH:mm",
+		"hm":    "h:mm a",
+	},
+}
+
+func TestTimeFormat(t *testing.T) {
+	tm := Time(time.Date(2024, time.March, 9, 14, 5, 7, 123456789, time.FixedZone("CET", 3600)))
+
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "y-MM-dd", expected: "2024-03-09"},
+		{pattern: "yy", expected: "24"},
+		{pattern: "M/d/y", expected: "3/9/2024"},
+		{pattern: "MMM", expected: "Mar"},
+		{pattern: "MMMM", expected: "March"},
+		{pattern: "MMMMM", expected: "M"},
+		{pattern: "E", expected: "Sat"},
+		{pattern: "EEEE", expected: "Saturday"},
+		{pattern: "EEEEE", expected: "S"},
+		{pattern: "e", expected: "7"},
+		{pattern: "QQ", expected: "01"},
+		{pattern: "D", expected: "69"},
+		{pattern: "G", expected: "AD"},
+		{pattern: "GGGG", expected: "Anno Domini"},
+		{pattern: "h:mm a", expected: "2:05 PM"},
+		{pattern: "HH:mm:ss", expected: "14:05:07"},
+		{pattern: "K k", expected: "2 14"},
+		{pattern: "ss.SSS", expected: "07.123"},
+		{pattern: "z", expected: "CET"},
+		{pattern: "Z", expected: "+0100"},
+		{pattern: "ZZZZ", expected: "GMT+01:00"},
+		{pattern: "O", expected: "GMT+1"},
+		{pattern: "XXX", expected: "+01:00"},
+		{pattern: "x", expected: "+01"},
+		{pattern: "'week' d", expected: "week 9"},
+		{pattern: "h 'o''clock'", expected: "2 o'clock"},
+		{pattern: "''", expected: "'"},
+	}
+
+	for _, test := range tests {
+		var w writer
+		tm.format(&w, test.pattern, &testCalendar, '0')
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected time format for %q: %q", test.pattern, s)
+		}
+	}
+}
+
+func TestTimeFormatSpecialValues(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		pattern  string
+		zero     rune
+		expected string
+	}{
+		{
+			time:     time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "y G",
+			zero:     '0',
+			expected: "1 BC",
+		},
+		{
+			time:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "h k X",
+			zero:     '0',
+			expected: "12 24 Z",
+		},
+		{
+			time:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			pattern:  "ZZZZ",
+			zero:     '0',
+			expected: "GMT",
+		},
+		{
+			time:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.FixedZone("", -(5*3600+30*60))),
+			pattern:  "O",
+			zero:     '0',
+			expected: "GMT-5:30",
+		},
+		{
+			time:     time.Date(2024, time.December, 30, 0, 0, 0, 0, time.UTC),
+			pattern:  "y Y",
+			zero:     '0',
+			expected: "2024 2025",
+		},
+		{
+			time:     time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+			pattern:  "dd.MM.y",
+			zero:     '٠',
+			expected: "٣١.١٢.٢٠٢٤",
+		},
+	}
+
+	for _, test := range tests {
+		var w writer
+		Time(test.time).format(&w, test.pattern, &testCalendar, test.zero)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected time format for %q: %q", test.expected, s)
+		}
+	}
+
+	// missing names fall back to numeric values
+	var w writer
+	Time(time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC)).format(&w, "MMM EEEE", &lxn.Calendar{}, '0')
+	if s := w.String(); s != "3 7" {
+		t.Errorf("unexpected time format for missing names: %q", s)
+	}
+}
+
+func TestTimePattern(t *testing.T) {
+	tests := []struct {
+		details  lxn.TimeDetails
+		expected string
+	}{
+		{
+			details:  lxn.TimeDetails{},
+			expected: "MMM d, y, h:mm:ss a",
+		},
+		{
+			details:  lxn.TimeDetails{DateStyle: lxn.DateTimeShort},
+			expected: "M/d/yy",
+		},
+		{
+			details:  lxn.TimeDetails{TimeStyle: lxn.DateTimeShort},
+			expected: "h:mm a",
+		},
+		{
+			details:  lxn.TimeDetails{DateStyle: lxn.DateTimeLong, TimeStyle: lxn.DateTimeShort},
+			expected: "MMMM d, y 'at' h:mm a",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "yMMMd"},
+			expected: "MMM d, y",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "yMMMMd"},
+			expected: "MMMM d, y",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "yMMdd"},
+			expected: "MM/dd/y",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "MMMMEEEEd"},
+			expected: "EEEE, MMMM d",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "Hm"},
+			expected: "HH:mm",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "jm"},
+			expected: "h:mm a",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "yMMMdHm"},
+			expected: "MMM d, y, HH:mm",
+		},
+		{
+			details:  lxn.TimeDetails{Skeleton: "MMMMEdhm"},
+			expected: "E, MMMM d 'at' h:mm a",
+		},
+	}
+
+	for _, test := range tests {
+		pattern, err := timePattern(&test.details, &testCalendar)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.expected, err)
+		case pattern != test.expected:
+			t.Errorf("unexpected time pattern for %q: %q", test.expected, pattern)
+		}
+	}
+
+	invalid := []string{"yMMMMw", "Hms", "yQQQ", "Y", "yMMMdd'", "yMMy"}
+	for _, skeleton := range invalid {
+		if _, err := timePattern(&lxn.TimeDetails{Skeleton: skeleton}, &testCalendar); err == nil {
+			t.Errorf("expected error for skeleton %q", skeleton)
+		}
+	}
+
+	cal := testCalendar
+	cal.DateFormats.Medium = "MMM d, y (w)"
+	if _, err := timePattern(&lxn.TimeDetails{}, &cal); err == nil {
+		t.Errorf("expected error for unsupported pattern field")
+	}
+}
@@ -0,0 +1,282 @@
+package lxn
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+var _ number = Decimal{}
+
+// maxDecimalExponent is the maximum magnitude of a parsed decimal number, i.e.
+// the number of integer digits or leading zeros in the fraction. It keeps the
+// formatted numbers at a reasonable size.
+const maxDecimalExponent = 10000
+
+// Decimal is an arbitrary-precision decimal variable which can be passed to
+// message replacements. In contrast to Float, a decimal number is formatted
+// exactly, i.e. without going through a binary floating-point representation.
+//
+// The zero value of a Decimal represents the number zero.
+type Decimal struct {
+	neg    bool
+	coeff  string // significant digits ('0'-'9') without leading and trailing zeros
+	intLen int    // number of integer digits, i.e. the value is 0.coeff × 10^intLen
+}
+
+// ParseDecimal parses a decimal number. The number consists of an optional sign,
+// integer digits, an optional fraction, and an optional exponent, e.g. "-1234.5678"
+// or "1.5e-3". The magnitude of the number is limited to 10^±10000.
+func ParseDecimal(s string) (Decimal, error) {
+	str, neg := s, false
+	switch {
+	case strings.HasPrefix(str, "-"):
+		str, neg = str[1:], true
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+
+	coeff := make([]byte, 0, len(str))
+	intLen, hasPoint := 0, false
+	for ; str != ""; str = str[1:] {
+		if ch := str[0]; '0' <= ch && ch <= '9' {
+			coeff = append(coeff, ch)
+			if !hasPoint {
+				intLen++
+			}
+		} else if ch == '.' && !hasPoint {
+			hasPoint = true
+		} else {
+			break
+		}
+	}
+	if len(coeff) == 0 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	if str != "" {
+		if str[0] != 'e' && str[0] != 'E' {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		exp, err := strconv.Atoi(str[1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid exponent in decimal %q", s)
+		}
+		if exp < -maxDecimalExponent-len(coeff) || exp > maxDecimalExponent+len(coeff) {
+			return Decimal{}, fmt.Errorf("exponent out of range in decimal %q", s)
+		}
+		intLen += exp
+	}
+
+	d := newDecimal(neg, coeff, intLen)
+	if d.intLen < -maxDecimalExponent || d.intLen > maxDecimalExponent {
+		return Decimal{}, fmt.Errorf("exponent out of range in decimal %q", s)
+	}
+	return d, nil
+}
+
+// maxBinaryExponent is the maximum binary exponent of a floating-point number
+// which can be converted into a decimal number, i.e. 2^maxBinaryExponent exceeds
+// 10^maxDecimalExponent.
+const maxBinaryExponent = 33220
+
+// NewDecimal returns the decimal number unscaled × 10^-scale. As for ParseDecimal,
+// the magnitude of the number is limited to 10^±10000.
+func NewDecimal(unscaled *big.Int, scale int) (Decimal, error) {
+	neg := unscaled.Sign() < 0
+	coeff := new(big.Int).Abs(unscaled).Append(nil, 10)
+	d := newDecimal(neg, coeff, len(coeff)-scale)
+	if d.intLen < -maxDecimalExponent || d.intLen > maxDecimalExponent {
+		return Decimal{}, fmt.Errorf("scale %d out of range for decimal", scale)
+	}
+	return d, nil
+}
+
+// DecimalFromBigFloat returns the exact decimal representation of the given
+// floating-point number. Infinite values and values whose magnitude exceeds
+// 10^±10000 cannot be represented and lead to an error.
+func DecimalFromBigFloat(f *big.Float) (Decimal, error) {
+	if f.IsInf() {
+		return Decimal{}, fmt.Errorf("cannot represent %v as a decimal", f)
+	}
+
+	// f = mant × 2^exp, where mant is an integer
+	mant := new(big.Float)
+	exp := f.MantExp(mant)
+	if exp < -maxBinaryExponent || exp > maxBinaryExponent {
+		return Decimal{}, fmt.Errorf("exponent 2^%d out of range for decimal", exp)
+	}
+	prec := int(mant.MinPrec())
+	mant.SetMantExp(mant, prec)
+	exp -= prec
+
+	unscaled, _ := mant.Int(nil)
+	if exp >= 0 {
+		return NewDecimal(unscaled.Lsh(unscaled, uint(exp)), 0)
+	}
+	// mant / 2^k = mant × 5^k / 10^k
+	pow5 := new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(-exp)), nil)
+	return NewDecimal(unscaled.Mul(unscaled, pow5), -exp)
+}
+
+// toDecimal converts a number into a decimal number. Floating-point numbers
+// which are not finite cannot be converted.
+func toDecimal(num number) (Decimal, bool) {
+	switch num := num.(type) {
+	case Decimal:
+		return num, true
+	case Float:
+		if math.IsNaN(float64(num)) || math.IsInf(float64(num), 0) {
+			return Decimal{}, false
+		}
+		d, err := ParseDecimal(strconv.FormatFloat(float64(num), 'e', -1, 64))
+		return d, err == nil
+	}
+	d, err := ParseDecimal(num.String())
+	return d, err == nil
+}
+
+// newDecimal normalizes the coefficient digits by removing leading and trailing
+// zeros.
+func newDecimal(neg bool, coeff []byte, intLen int) Decimal {
+	for len(coeff) > 0 && coeff[0] == '0' {
+		coeff = coeff[1:]
+		intLen--
+	}
+	for len(coeff) > 0 && coeff[len(coeff)-1] == '0' {
+		coeff = coeff[:len(coeff)-1]
+	}
+	if len(coeff) == 0 {
+		return Decimal{}
+	}
+	return Decimal{neg: neg, coeff: string(coeff), intLen: intLen}
+}
+
+// String implements the Variable interface.
+func (d Decimal) String() string {
+	if d.coeff == "" {
+		return "0"
+	}
+
+	var sb strings.Builder
+	if d.neg {
+		sb.WriteByte('-')
+	}
+	if d.intLen <= 0 {
+		sb.WriteString("0.")
+		sb.WriteString(strings.Repeat("0", -d.intLen))
+		sb.WriteString(d.coeff)
+		return sb.String()
+	}
+
+	sb.WriteString(d.digitRange(0, d.intLen))
+	if d.intLen < len(d.coeff) {
+		sb.WriteByte('.')
+		sb.WriteString(d.coeff[d.intLen:])
+	}
+	return sb.String()
+}
+
+// IsZero reports whether the decimal number is zero.
+func (d Decimal) IsZero() bool {
+	return d.coeff == ""
+}
+
+// shift returns the decimal number multiplied with 10^n.
+func (d Decimal) shift(n int) Decimal {
+	if d.coeff != "" {
+		d.intLen += n
+	}
+	return d
+}
+
+// digitRange returns the coefficient digits in the range [from, to), padded with
+// zeros if the range exceeds the coefficient.
+func (d Decimal) digitRange(from, to int) string {
+	if to <= len(d.coeff) {
+		return d.coeff[from:to]
+	}
+	return d.coeff[min(from, len(d.coeff)):] + strings.Repeat("0", to-max(from, len(d.coeff)))
+}
+
+// round rounds the decimal number to the given number of fraction digits using
+// the rounding mode.
+func (d Decimal) round(fracDigits int, mode lxn.RoundingMode) Decimal {
+	if d.intLen+fracDigits >= len(d.coeff) {
+		return d
+	}
+	coeff, intLen := roundDigits([]byte(d.coeff), d.intLen, fracDigits, d.neg, mode)
+	return newDecimal(d.neg, coeff, intLen)
+}
+
+// unscaled returns the decimal number multiplied with 10^scale. The fraction
+// digits beyond the scale are truncated.
+func (d Decimal) unscaled(scale int) *big.Int {
+	n := d.intLen + scale
+	if n <= 0 {
+		return new(big.Int)
+	}
+	i, _ := new(big.Int).SetString(d.digitRange(0, n), 10)
+	if d.neg {
+		i.Neg(i)
+	}
+	return i
+}
+
+// returns (integer digits, fraction digits)
+func (d Decimal) digits(buf []rune, nf *lxn.NumberFormat, zero rune) ([]rune, []rune) {
+	coeff, intLen, minFrac := roundNumber([]byte(d.coeff), d.intLen, d.neg, nf)
+	return coeffDigits(buf, coeff, intLen, minFrac, nf.MinIntegerDigits, zero)
+}
+
+// coeffDigits returns the integer and fraction digits of the number
+// 0.coeff × 10^intLen. The fraction digits are padded to the minimum number of
+// fraction digits and the integer digits to the minimum number of integer digits.
+// If the buffer is too small, a new one will be allocated.
+func coeffDigits(buf []rune, coeff []byte, intLen int, minFrac int, minInt int, zero rune) ([]rune, []rune) {
+	digit := func(pos int) rune {
+		if pos < 0 || pos >= len(coeff) {
+			return 0
+		}
+		return rune(coeff[pos] - '0')
+	}
+
+	if len(coeff) == 0 {
+		intLen = 0 // the number is zero
+	}
+
+	numFrac := max(len(coeff)-intLen, minFrac, 0)
+	numInt := max(intLen, minInt, 1)
+	if n := numInt + numFrac; n > len(buf) {
+		buf = make([]rune, n)
+	}
+
+	// fractional digits
+	fracidx := len(buf) - numFrac
+	for i := 0; i < numFrac; i++ {
+		buf[fracidx+i] = zero + digit(intLen+i)
+	}
+
+	// integer digits
+	intidx := fracidx - numInt
+	for i := 0; i < numInt; i++ {
+		buf[intidx+i] = zero + digit(intLen-numInt+i)
+	}
+
+	return buf[intidx:fracidx], buf[fracidx:]
+}
+
+func (d Decimal) format(w *writer, nf *lxn.NumberFormat, currency string) {
+	var buf [maxFloatDigits]rune
+	intDigits, fracDigits := d.digits(buf[:], nf, rune(nf.Symbols.Zero))
+	prefix, suffix := signAffixes(nf, d.neg, isZero(intDigits, fracDigits, rune(nf.Symbols.Zero)))
+
+	w.WriteAffix(prefix, &nf.Symbols, currency)
+	w.WriteInt(intDigits, nf)
+	w.WriteFrac(fracDigits, nf)
+	w.WriteAffix(suffix, &nf.Symbols, currency)
+}
@@ -0,0 +1,394 @@
+package lxn
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func mustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected string
+	}{
+		{str: "0", expected: "0"},
+		{str: "-0.000", expected: "0"},
+		{str: "123", expected: "123"},
+		{str: "+123", expected: "123"},
+		{str: "-123", expected: "-123"},
+		{str: "001200", expected: "1200"},
+		{str: "12.3400", expected: "12.34"},
+		{str: "0.05", expected: "0.05"},
+		{str: ".5", expected: "0.5"},
+		{str: "5.", expected: "5"},
+		{str: "1.5e3", expected: "1500"},
+		{str: "1.5E-3", expected: "0.0015"},
+		{str: "123456789012345678901234567890.123456789", expected: "123456789012345678901234567890.123456789"},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.str)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.str, err)
+		case d.String() != test.expected:
+			t.Errorf("unexpected decimal for %q: %s", test.str, d.String())
+		}
+	}
+
+	invalid := []string{
+		"", "-", ".", "1.2.3", "abc", "12a", "1e", "1e+", "1e3.5",
+		"1e9000000000000000000", "1e1000000000", "1e-1000000000", "1e10001", "0.01e-10000",
+	}
+	for _, str := range invalid {
+		if _, err := ParseDecimal(str); err == nil {
+			t.Errorf("expected error for %q", str)
+		}
+	}
+
+	// numbers at the limit have to be formattable
+	nf := lxn.NumberFormat{
+		Symbols:           lxn.Symbols{Zero: '0', Decimal: "."},
+		MaxFractionDigits: maxDecimalExponent + 1,
+	}
+	limits := map[string]int{"1e9999": maxDecimalExponent, "1e-10000": maxDecimalExponent + 2}
+	for str, length := range limits {
+		d, err := ParseDecimal(str)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", str, err)
+			continue
+		}
+		var w writer
+		d.format(&w, &nf, noCurrency)
+		if n := len(w.String()); n != length {
+			t.Errorf("unexpected format length for %q: %d", str, n)
+		}
+	}
+}
+
+func TestNewDecimal(t *testing.T) {
+	tests := []struct {
+		unscaled int64
+		scale    int
+		expected string
+	}{
+		{unscaled: 0, scale: 2, expected: "0"},
+		{unscaled: 12345, scale: 2, expected: "123.45"},
+		{unscaled: -12345, scale: 0, expected: "-12345"},
+		{unscaled: 12345, scale: -2, expected: "1234500"},
+		{unscaled: 5, scale: 3, expected: "0.005"},
+	}
+
+	for _, test := range tests {
+		d, err := NewDecimal(big.NewInt(test.unscaled), test.scale)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.expected, err)
+		case d.String() != test.expected:
+			t.Errorf("unexpected decimal for %q: %s", test.expected, d.String())
+		}
+	}
+
+	outOfRange := []struct {
+		unscaled int64
+		scale    int
+	}{
+		{unscaled: 1, scale: -10000},
+		{unscaled: 1, scale: 10002},
+		{unscaled: 1, scale: math.MinInt},
+		{unscaled: 1, scale: math.MaxInt},
+	}
+	for _, test := range outOfRange {
+		if _, err := NewDecimal(big.NewInt(test.unscaled), test.scale); err == nil {
+			t.Errorf("expected error for %d×10^-%d", test.unscaled, test.scale)
+		}
+	}
+	if d, err := NewDecimal(big.NewInt(0), math.MinInt); err != nil || d.String() != "0" {
+		t.Errorf("unexpected decimal for zero: %s (%v)", d.String(), err)
+	}
+}
+
+func TestDecimalFromBigFloat(t *testing.T) {
+	tests := []struct {
+		f        *big.Float
+		expected string
+	}{
+		{f: big.NewFloat(0), expected: "0"},
+		{f: big.NewFloat(1024), expected: "1024"},
+		{f: big.NewFloat(-0.375), expected: "-0.375"},
+		{f: big.NewFloat(0.1), expected: "0.1000000000000000055511151231257827021181583404541015625"},
+	}
+
+	for _, test := range tests {
+		d, err := DecimalFromBigFloat(test.f)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.expected, err)
+		case d.String() != test.expected:
+			t.Errorf("unexpected decimal for %q: %s", test.expected, d.String())
+		}
+	}
+
+	if _, err := DecimalFromBigFloat(new(big.Float).SetInf(false)); err == nil {
+		t.Errorf("expected error for infinite value")
+	}
+
+	outOfRange := []*big.Float{
+		new(big.Float).SetMantExp(big.NewFloat(1), -2000000),
+		new(big.Float).SetMantExp(big.NewFloat(-1), 2000000),
+		new(big.Float).SetMantExp(big.NewFloat(1), 33220),
+	}
+	for i, f := range outOfRange {
+		if _, err := DecimalFromBigFloat(f); err == nil {
+			t.Errorf("expected error for value %d", i)
+		}
+	}
+	if _, err := DecimalFromBigFloat(new(big.Float).SetMantExp(big.NewFloat(1), -33000)); err != nil {
+		t.Errorf("unexpected error for 2^-33000: %v", err)
+	}
+}
+
+func TestDecimalDigits(t *testing.T) {
+	tests := []struct {
+		val          string
+		nf           lxn.NumberFormat
+		expectedInt  string
+		expectedFrac string
+	}{
+		{
+			val:          "0",
+			expectedInt:  "0",
+			expectedFrac: "",
+		},
+		{
+			val:          "-123",
+			expectedInt:  "123",
+			expectedFrac: "",
+		},
+		{
+			val: "123",
+			nf: lxn.NumberFormat{
+				MinFractionDigits: 2,
+				MaxFractionDigits: 2,
+			},
+			expectedInt:  "123",
+			expectedFrac: "00",
+		},
+		{
+			val: "123",
+			nf: lxn.NumberFormat{
+				MinIntegerDigits: 5,
+			},
+			expectedInt:  "00123",
+			expectedFrac: "",
+		},
+		{
+			val: "0.05",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 3,
+			},
+			expectedInt:  "0",
+			expectedFrac: "05",
+		},
+		{
+			val: "1200",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 3,
+			},
+			expectedInt:  "1200",
+			expectedFrac: "",
+		},
+		{
+			val: "123.125",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 2,
+			},
+			expectedInt:  "123",
+			expectedFrac: "12",
+		},
+		{
+			val: "123.135",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 2,
+			},
+			expectedInt:  "123",
+			expectedFrac: "14",
+		},
+		{
+			val: "123.1251",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 2,
+			},
+			expectedInt:  "123",
+			expectedFrac: "13",
+		},
+		{
+			val: "99.999",
+			nf: lxn.NumberFormat{
+				MinFractionDigits: 1,
+				MaxFractionDigits: 2,
+			},
+			expectedInt:  "100",
+			expectedFrac: "0",
+		},
+		{
+			val: "0.5",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 0,
+			},
+			expectedInt:  "0",
+			expectedFrac: "",
+		},
+		{
+			val: "0.006",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 2,
+			},
+			expectedInt:  "0",
+			expectedFrac: "01",
+		},
+		{
+			val: "9007199254740993.25",
+			nf: lxn.NumberFormat{
+				MinFractionDigits: 2,
+				MaxFractionDigits: 2,
+			},
+			expectedInt:  "9007199254740993",
+			expectedFrac: "25",
+		},
+		{
+			val: "-2.5",
+			nf: lxn.NumberFormat{
+				RoundingMode: lxn.RoundHalfUp,
+			},
+			expectedInt:  "3",
+			expectedFrac: "",
+		},
+		{
+			val: "0.001",
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 2,
+				RoundingMode:      lxn.RoundCeiling,
+			},
+			expectedInt:  "0",
+			expectedFrac: "01",
+		},
+		{
+			val: "123456789012345678901234567890",
+			nf: lxn.NumberFormat{
+				MaxSignificantDigits: 3,
+			},
+			expectedInt:  "123000000000000000000000000000",
+			expectedFrac: "",
+		},
+	}
+
+	var buf [maxFloatDigits]rune
+	for _, test := range tests {
+		d, err := ParseDecimal(test.val)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", test.val, err)
+		}
+
+		intDigits, fracDigits := d.digits(buf[:], &test.nf, '0')
+		if string(intDigits) != test.expectedInt {
+			t.Errorf("unexpected integer digits for %s: %s", test.val, string(intDigits))
+		}
+		if string(fracDigits) != test.expectedFrac {
+			t.Errorf("unexpected fractional digits for %s: %s", test.val, string(fracDigits))
+		}
+	}
+}
+
+func TestDecimalDigitsExceedingBuffer(t *testing.T) {
+	d, err := NewDecimal(new(big.Int).Exp(big.NewInt(10), big.NewInt(300), nil), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf [maxFloatDigits]rune
+	intDigits, fracDigits := d.digits(buf[:], &lxn.NumberFormat{}, '0')
+	if len(intDigits) != 301 || intDigits[0] != '1' {
+		t.Errorf("unexpected integer digits: %s", string(intDigits))
+	}
+	if len(fracDigits) != 0 {
+		t.Errorf("unexpected fractional digits: %s", string(fracDigits))
+	}
+}
+
+func TestDecimalFormat(t *testing.T) {
+	tests := []struct {
+		val      string
+		nf       lxn.NumberFormat
+		expected string
+	}{
+		{
+			val: "0",
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero:    '0',
+					Decimal: ":",
+				},
+				PositivePrefix:    "p",
+				PositiveSuffix:    "s",
+				MinFractionDigits: 2,
+				MaxFractionDigits: 5,
+			},
+			expected: "p0:00s",
+		},
+		{
+			val: "1234567.125",
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero:    '0',
+					Decimal: ":",
+					Group:   "#",
+				},
+				PositivePrefix:           "p",
+				PositiveSuffix:           "s",
+				MinFractionDigits:        1,
+				MaxFractionDigits:        2,
+				PrimaryIntegerGrouping:   3,
+				SecondaryIntegerGrouping: 3,
+			},
+			expected: "p1#234#567:12s",
+		},
+		{
+			val: "-123.12",
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero:    '0',
+					Decimal: ":",
+				},
+				NegativePrefix:    "np",
+				NegativeSuffix:    "ns",
+				MinFractionDigits: 3,
+				MaxFractionDigits: 5,
+			},
+			expected: "np123:120ns",
+		},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDecimal(test.val)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", test.val, err)
+		}
+
+		var w writer
+		d.format(&w, &test.nf, noCurrency)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected Decimal format for %q: %s", test.expected, s)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package lxn
 
 import (
+	"errors"
+	"fmt"
 	"io"
 
 	"github.com/mprot/msgpack-go"
@@ -17,15 +19,51 @@ type Dictionary struct {
 	cat *Catalog
 }
 
+// NewDictionary creates a dictionary from the locale and the catalogs. All
+// catalogs must belong to the given locale. Messages which are defined in more
+// than one catalog lead to an error, catalogs with overlapping messages need to
+// be merged with MergeCatalogsWith beforehand.
+func NewDictionary(loc *Locale, cats ...*Catalog) (*Dictionary, error) {
+	if loc == nil {
+		return nil, errors.New("missing locale for dictionary")
+	}
+	for _, cat := range cats {
+		if cat == nil {
+			return nil, errors.New("missing catalog for dictionary")
+		}
+		if !sameLocale(cat.LocaleID(), loc.ID()) {
+			return nil, fmt.Errorf("catalog for locale %s does not match dictionary locale %s", cat.LocaleID(), loc.ID())
+		}
+	}
+
+	cat, _, err := MergeCatalogsWith(ErrorOnConflict, cats...)
+	if err != nil {
+		return nil, err
+	}
+	if cat == nil {
+		cat = &Catalog{localeID: loc.ID(), msgs: messageMap{}}
+	}
+
+	return &Dictionary{
+		loc: loc,
+		cat: cat,
+	}, nil
+}
+
 func ReadDictionary(r io.Reader) (*Dictionary, error) {
 	dic := &lxn.Dictionary{}
 	if err := msgpack.Decode(r, dic); err != nil {
 		return nil, err
 	}
 
+	cat, err := newCatalog(dic.Locale.ID, dic.Messages)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Dictionary{
 		loc: newLocale(dic.Locale),
-		cat: newCatalog(dic.Locale.ID, dic.Messages),
+		cat: cat,
 	}, nil
 }
 
@@ -33,6 +71,30 @@ func (d *Dictionary) Locale() *Locale {
 	return d.loc
 }
 
+// WithLocale returns a copy of the dictionary which formats the messages with the
+// given locale, e.g. a locale derived with WithExtensions. The locale must refer
+// to the dictionary's locale. The messages are shared with the original
+// dictionary.
+func (d *Dictionary) WithLocale(loc *Locale) (*Dictionary, error) {
+	if !sameLocale(loc.ID(), d.loc.ID()) {
+		return nil, fmt.Errorf("locale %s does not match dictionary locale %s", loc.ID(), d.loc.ID())
+	}
+	return &Dictionary{
+		loc: loc,
+		cat: d.cat,
+	}, nil
+}
+
+// WithPercentScaling returns a copy of the dictionary which treats percent values
+// as ratios, i.e. 0.25 is formatted as 25%. The messages are shared with the
+// original dictionary.
+func (d *Dictionary) WithPercentScaling() *Dictionary {
+	return &Dictionary{
+		loc: d.loc.WithPercentScaling(),
+		cat: d.cat,
+	}
+}
+
 func (d *Dictionary) Translate(section string, messageKey string, ctx Context) string {
 	msg := d.cat.Message(section, messageKey)
 	if msg == nil {
@@ -40,3 +102,40 @@ func (d *Dictionary) Translate(section string, messageKey string, ctx Context) s
 	}
 	return msg.Format(d.loc, ctx)
 }
+
+// Ref returns a reference to the message with the given section and message key.
+// The reference can be cached by the caller to translate the message repeatedly
+// without looking it up again. If the message cannot be found, the reference will
+// be invalid.
+func (d *Dictionary) Ref(section string, messageKey string) MessageRef {
+	msg := d.cat.Message(section, messageKey)
+	if msg == nil {
+		return MessageRef{}
+	}
+	return MessageRef{loc: d.loc, msg: msg}
+}
+
+// MessageRef is a resolved reference to a message of a dictionary. The zero value
+// is an invalid reference, which translates to an empty string.
+type MessageRef struct {
+	loc *Locale
+	msg *Message
+}
+
+// Valid reports whether the reference points to a message.
+func (r MessageRef) Valid() bool {
+	return r.msg != nil
+}
+
+// Message returns the referenced message or nil for an invalid reference.
+func (r MessageRef) Message() *Message {
+	return r.msg
+}
+
+// Translate formats the referenced message with the given context.
+func (r MessageRef) Translate(ctx Context) string {
+	if r.msg == nil {
+		return ""
+	}
+	return r.msg.Format(r.loc, ctx)
+}
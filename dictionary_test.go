@@ -0,0 +1,215 @@
+package lxn
+
+import (
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestDictionaryRef(t *testing.T) {
+	cat, err := newCatalog("en", []lxn.Message{
+		{Key: "key", Text: []string{"no section"}},
+		{Section: "section", Key: "key", Text: []string{"with section"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dic := &Dictionary{loc: newLocale(lxn.Locale{ID: "en"}), cat: cat}
+
+	tests := []struct {
+		section  string
+		key      string
+		valid    bool
+		expected string
+	}{
+		{section: "", key: "key", valid: true, expected: "no section"},
+		{section: "section", key: "key", valid: true, expected: "with section"},
+		{section: "section", key: "unknown"},
+		{section: "unknown", key: "key"},
+	}
+
+	for _, test := range tests {
+		ref := dic.Ref(test.section, test.key)
+		switch {
+		case ref.Valid() != test.valid:
+			t.Errorf("unexpected validity for %s.%s: %v", test.section, test.key, ref.Valid())
+		case ref.Translate(nil) != test.expected:
+			t.Errorf("unexpected translation for %s.%s: %q", test.section, test.key, ref.Translate(nil))
+		case ref.Translate(nil) != dic.Translate(test.section, test.key, nil):
+			t.Errorf("unexpected dictionary translation for %s.%s", test.section, test.key)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		dic.cat.Message("section", "key")
+	})
+	if allocs != 0 {
+		t.Errorf("unexpected allocations for message lookup: %v", allocs)
+	}
+}
+
+func BenchmarkDictionaryTranslate(b *testing.B) {
+	nf := lxn.NumberFormat{
+		Symbols:                  lxn.Symbols{Zero: '0', Decimal: ".", Group: ",", Minus: "-", Percent: "%"},
+		MaxFractionDigits:        3,
+		PrimaryIntegerGrouping:   3,
+		SecondaryIntegerGrouping: 3,
+	}
+	loc := lxn.Locale{
+		ID:            "en",
+		DecimalFormat: nf,
+		MoneyFormat: lxn.NumberFormat{
+			Symbols:                  nf.Symbols,
+			PositivePrefix:           "¤",
+			NegativePrefix:           "-¤",
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		Currencies: map[string]lxn.Currency{
+			"USD": {Symbol: "$", FractionDigits: 2},
+		},
+		CardinalPlurals: []lxn.Plural{
+			{
+				Category: lxn.One,
+				Rules: []lxn.PluralRule{
+					{Operand: lxn.AbsoluteValue, Ranges: []lxn.Range{{LowerBound: 1, UpperBound: 1}}},
+				},
+			},
+		},
+	}
+
+	repl := func(key string, typ lxn.ReplacementType, details interface{}) lxn.Replacement {
+		return lxn.Replacement{Key: key, TextPos: 1, Type: typ, Details: lxn.ReplacementDetails{Value: details}}
+	}
+
+	msgs := []lxn.Message{
+		{
+			Key:  "text",
+			Text: []string{"Welcome ", "back!"},
+		},
+		{
+			Key:          "string",
+			Text:         []string{"Hello ", "!"},
+			Replacements: []lxn.Replacement{repl("name", lxn.StringReplacement, nil)},
+		},
+		{
+			Key:          "number",
+			Text:         []string{"You have ", " points."},
+			Replacements: []lxn.Replacement{repl("count", lxn.NumberReplacement, lxn.NumberDetails{})},
+		},
+		{
+			Key:          "skeleton",
+			Text:         []string{"Ratio: ", ""},
+			Replacements: []lxn.Replacement{repl("count", lxn.NumberReplacement, lxn.NumberDetails{Skeleton: ".00 group-off"})},
+		},
+		{
+			Key:          "money",
+			Text:         []string{"Total: ", ""},
+			Replacements: []lxn.Replacement{repl("amount", lxn.MoneyReplacement, lxn.MoneyDetails{Currency: "currency"})},
+		},
+		{
+			Key:  "plural",
+			Text: []string{"You have ", "."},
+			Replacements: []lxn.Replacement{repl("count", lxn.PluralReplacement, lxn.PluralDetails{
+				Variants: map[lxn.PluralCategory]lxn.Message{
+					lxn.One: {Text: []string{"one message"}},
+					lxn.Other: {
+						Text:         []string{"", " messages"},
+						Replacements: []lxn.Replacement{repl("count", lxn.NumberReplacement, lxn.NumberDetails{})},
+					},
+				},
+			})},
+		},
+		{
+			Key:  "select",
+			Text: []string{"", " replied."},
+			Replacements: []lxn.Replacement{repl("gender", lxn.SelectReplacement, lxn.SelectDetails{
+				Cases: map[string]lxn.Message{
+					"female": {Text: []string{"She"}},
+					"male":   {Text: []string{"He"}},
+					"other":  {Text: []string{"They"}},
+				},
+				Fallback: "other",
+			})},
+		},
+	}
+
+	cat, err := newCatalog(loc.ID, msgs)
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	dic := &Dictionary{loc: newLocale(loc), cat: cat}
+	ctx := Context{
+		"name":     String("Jane"),
+		"count":    Int(12345),
+		"amount":   Float(1234.5),
+		"currency": String("USD"),
+		"gender":   String("unknown"),
+	}
+
+	for _, msg := range msgs {
+		b.Run(msg.Key, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dic.Translate("", msg.Key, ctx)
+			}
+		})
+	}
+
+	b.Run("ref", func(b *testing.B) {
+		ref := dic.Ref("", "text")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ref.Translate(ctx)
+		}
+	})
+}
+
+func TestNewDictionary(t *testing.T) {
+	newCat := func(localeID string, keys ...string) *Catalog {
+		msgs := make([]lxn.Message, 0, len(keys))
+		for _, key := range keys {
+			msgs = append(msgs, lxn.Message{Key: key, Text: []string{localeID + ":" + key}})
+		}
+		cat, err := newCatalog(localeID, msgs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return cat
+	}
+
+	loc := newLocale(lxn.Locale{ID: "en"})
+	dic, err := NewDictionary(loc, newCat("en", "a", "b"), newCat("en", "c"))
+	switch {
+	case err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	case dic.Locale() != loc:
+		t.Errorf("unexpected locale: %v", dic.Locale())
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		if got := dic.Translate("", key, nil); got != "en:"+key {
+			t.Errorf("unexpected translation for %s: %q", key, got)
+		}
+	}
+
+	dic, err = NewDictionary(loc)
+	switch {
+	case err != nil:
+		t.Errorf("unexpected error without catalogs: %v", err)
+	case dic.Translate("", "a", nil) != "":
+		t.Errorf("unexpected translation without catalogs")
+	}
+
+	if _, err := NewDictionary(loc, newCat("en", "a"), newCat("de", "b")); err == nil {
+		t.Errorf("expected error for mismatching locale")
+	}
+	if _, err := NewDictionary(loc, newCat("en", "a"), newCat("en", "a")); err == nil {
+		t.Errorf("expected error for conflicting messages")
+	}
+	if _, err := NewDictionary(nil, newCat("en", "a")); err == nil {
+		t.Errorf("expected error for missing locale")
+	}
+	if _, err := NewDictionary(loc, newCat("en", "a"), nil); err == nil {
+		t.Errorf("expected error for missing catalog")
+	}
+}
@@ -0,0 +1,183 @@
+package lxn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// numberingSystem describes the digits of a numbering system. Numbering systems
+// which come with their own symbols (e.g. the Arabic decimal separator) carry
+// these symbols as well.
+type numberingSystem struct {
+	zero    rune
+	symbols *lxn.Symbols // nil if the locale's symbols can be used
+}
+
+// latnSymbols are the symbols used when switching from a numbering system with
+// its own symbols to one without.
+var latnSymbols = lxn.Symbols{
+	Decimal:     ".",
+	Group:       ",",
+	Percent:     "%",
+	PerMille:    "‰",
+	Minus:       "-",
+	Plus:        "+",
+	Exponential: "E",
+}
+
+var numberingSystems = map[string]numberingSystem{
+	"latn": {zero: '0'},
+	"arab": {zero: '٠', symbols: &lxn.Symbols{
+		Decimal:     "٫",
+		Group:       "٬",
+		Percent:     "٪؜",
+		PerMille:    "؉",
+		Minus:       "؜-",
+		Plus:        "؜+",
+		Exponential: "اس",
+	}},
+	"arabext": {zero: '۰', symbols: &lxn.Symbols{
+		Decimal:     "٫",
+		Group:       "٬",
+		Percent:     "٪",
+		PerMille:    "؉",
+		Minus:       "‎−",
+		Plus:        "‎+",
+		Exponential: "×۱۰^",
+	}},
+	"beng":     {zero: '০'},
+	"deva":     {zero: '०'},
+	"fullwide": {zero: '０'},
+	"gujr":     {zero: '૦'},
+	"guru":     {zero: '੦'},
+	"khmr":     {zero: '០'},
+	"knda":     {zero: '೦'},
+	"laoo":     {zero: '໐'},
+	"mlym":     {zero: '൦'},
+	"mong":     {zero: '᠐'},
+	"mymr":     {zero: '၀'},
+	"orya":     {zero: '୦'},
+	"tamldec":  {zero: '௦'},
+	"telu":     {zero: '౦'},
+	"thai":     {zero: '๐'},
+	"tibt":     {zero: '༠'},
+}
+
+// WithExtensions returns a copy of the locale with the Unicode extension of the
+// tag applied. The following keywords are supported:
+//
+//	nu    numbering system, e.g. "latn" for Latin digits in an Arabic locale
+//	rg    region override, e.g. "dezzzz" for German number formats
+//
+// The number formats for a region override are taken from the locale returned
+// by regionLocale for the region code, i.e. an ISO 3166 code (e.g. "DE") or a
+// UN M.49 code (e.g. "419" for Latin America). All other keywords are ignored.
+// The extension is added to the id of the derived locale, so the derived locale
+// can be used with all catalogs of the original locale.
+func (l *Locale) WithExtensions(tag Tag, regionLocale func(region string) *Locale) (*Locale, error) {
+	loc := l
+	if rg := tag.Unicode("rg"); rg != "" {
+		region, ok := strings.CutSuffix(rg, "zzzz")
+		if !ok || !(len(region) == 2 && isAlpha(region) || len(region) == 3 && isDigits(region)) {
+			return nil, fmt.Errorf("invalid region override %q", rg)
+		}
+		region = strings.ToUpper(region)
+
+		var rloc *Locale
+		if regionLocale != nil {
+			rloc = regionLocale(region)
+		}
+		if rloc == nil {
+			return nil, fmt.Errorf("no locale for region override %s", region)
+		}
+		loc = loc.WithRegionFormats(rloc)
+		loc.loc.ID = withUnicodeKeyword(loc.loc.ID, "rg", rg)
+	}
+
+	if nu := tag.Unicode("nu"); nu != "" {
+		var err error
+		if loc, err = loc.WithNumberingSystem(nu); err != nil {
+			return nil, err
+		}
+	}
+	return loc, nil
+}
+
+// WithNumberingSystem returns a copy of the locale which formats numbers with the
+// digits of the given numbering system, e.g. "latn" or "arab". If the numbering
+// system comes with its own symbols, the symbols are replaced as well.
+func (l *Locale) WithNumberingSystem(nu string) (*Locale, error) {
+	ns, has := numberingSystems[nu]
+	if !has {
+		return nil, fmt.Errorf("unsupported numbering system %q", nu)
+	}
+
+	c := *l
+	c.loc.ID = withUnicodeKeyword(l.loc.ID, "nu", nu)
+	for _, nf := range c.numberFormats() {
+		if nf.Symbols.Zero == 0 {
+			continue // format does not exist
+		}
+
+		symbols := ns.symbols
+		if native := nativeNumberingSystem(rune(nf.Symbols.Zero)); native.symbols != nil && symbols == nil {
+			symbols = &latnSymbols
+		} else if native.zero == ns.zero {
+			symbols = nil
+		}
+		if symbols != nil {
+			nf.Symbols.Decimal = symbols.Decimal
+			nf.Symbols.Group = symbols.Group
+			nf.Symbols.Percent = symbols.Percent
+			nf.Symbols.PerMille = symbols.PerMille
+			nf.Symbols.Minus = symbols.Minus
+			nf.Symbols.Plus = symbols.Plus
+			nf.Symbols.Exponential = symbols.Exponential
+		}
+		nf.Symbols.Zero = uint32(ns.zero)
+	}
+	return &c, nil
+}
+
+// WithRegionFormats returns a copy of the locale which uses the decimal, percent,
+// and money formats of the given locale, e.g. German number formats in an English
+// locale. All language-dependent data is kept.
+func (l *Locale) WithRegionFormats(region *Locale) *Locale {
+	c := *l
+	c.loc.DecimalFormat = region.loc.DecimalFormat
+	c.loc.PercentFormat = region.loc.PercentFormat
+	c.loc.MoneyFormat = region.loc.MoneyFormat
+	c.loc.AccountingFormat = region.loc.AccountingFormat
+	return &c
+}
+
+// numberFormats returns all number formats of the locale.
+func (l *Locale) numberFormats() []*lxn.NumberFormat {
+	return []*lxn.NumberFormat{
+		&l.loc.DecimalFormat,
+		&l.loc.PercentFormat,
+		&l.loc.MoneyFormat,
+		&l.loc.AccountingFormat,
+	}
+}
+
+func nativeNumberingSystem(zero rune) numberingSystem {
+	for _, ns := range numberingSystems {
+		if ns.zero == zero {
+			return ns
+		}
+	}
+	return numberingSystem{zero: zero}
+}
+
+// withUnicodeKeyword sets the keyword of the Unicode extension in the locale id.
+// Ids which are no valid tags are returned as is.
+func withUnicodeKeyword(id string, key string, value string) string {
+	tag, err := ParseTag(id)
+	if err != nil {
+		return id
+	}
+	return tag.withUnicode(key, value).String()
+}
@@ -0,0 +1,130 @@
+package lxn
+
+import (
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestLocaleWithExtensions(t *testing.T) {
+	arabic := newLocale(lxn.Locale{
+		ID: "ar-EG",
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  lxn.Symbols{Zero: '٠', Decimal: "٫", Group: "٬", Minus: "؜-"},
+			NegativePrefix:           "-",
+			MaxFractionDigits:        3,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+	})
+	german := newLocale(lxn.Locale{
+		ID: "de-DE",
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  lxn.Symbols{Zero: '0', Decimal: ",", Group: ".", Minus: "-"},
+			NegativePrefix:           "-",
+			MaxFractionDigits:        3,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+	})
+	english := newLocale(lxn.Locale{
+		ID: "en-US",
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  lxn.Symbols{Zero: '0', Decimal: ".", Group: ",", Minus: "-"},
+			NegativePrefix:           "-",
+			MaxFractionDigits:        3,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+	})
+
+	regions := func(region string) *Locale {
+		switch region {
+		case "DE", "419":
+			return german
+		}
+		return nil
+	}
+
+	tests := []struct {
+		loc      *Locale
+		tag      string
+		id       string
+		expected string
+	}{
+		{loc: arabic, tag: "ar-EG", id: "ar-EG", expected: "؜-١٬٢٣٤٫٥"},
+		{loc: arabic, tag: "ar-EG-u-nu-latn", id: "ar-EG-u-nu-latn", expected: "-1,234.5"},
+		{loc: arabic, tag: "ar-EG-u-nu-arab", id: "ar-EG-u-nu-arab", expected: "؜-١٬٢٣٤٫٥"},
+		{loc: german, tag: "de-DE-u-nu-arab", id: "de-DE-u-nu-arab", expected: "؜-١٬٢٣٤٫٥"},
+		{loc: german, tag: "de-DE-u-nu-thai", id: "de-DE-u-nu-thai", expected: "-๑.๒๓๔,๕"},
+		{loc: english, tag: "en-US-u-rg-dezzzz", id: "en-US-u-rg-dezzzz", expected: "-1.234,5"},
+		{loc: english, tag: "en-US-u-rg-419zzzz", id: "en-US-u-rg-419zzzz", expected: "-1.234,5"},
+		{loc: english, tag: "en-US-u-nu-deva-rg-dezzzz", id: "en-US-u-nu-deva-rg-dezzzz", expected: "-१.२३४,५"},
+		{loc: english, tag: "en-u-ca-gregory", id: "en-US", expected: "-1,234.5"},
+	}
+
+	for _, test := range tests {
+		loc, err := test.loc.WithExtensions(MustParseTag(test.tag), regions)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %s: %v", test.tag, err)
+		case loc.ID() != test.id:
+			t.Errorf("unexpected id for %s: %s", test.tag, loc.ID())
+		case loc.FormatNumber(Float(-1234.5)) != test.expected:
+			t.Errorf("unexpected format for %s: %s", test.tag, loc.FormatNumber(Float(-1234.5)))
+		}
+	}
+
+	if arabic.FormatNumber(Int(1)) != "١" {
+		t.Errorf("unexpected modification of the original locale")
+	}
+
+	invalid := []string{"ar-u-nu-foo", "en-u-rg-frzzzz", "en-u-rg-dezz", "en-u-rg-d1zzzz", "en-u-rg-15azzzz"}
+	for _, tag := range invalid {
+		if _, err := english.WithExtensions(MustParseTag(tag), regions); err == nil {
+			t.Errorf("expected error for %s", tag)
+		}
+	}
+}
+
+func TestDictionaryWithLocale(t *testing.T) {
+	cat, err := newCatalog("ar-EG", []lxn.Message{
+		{
+			Key:          "count",
+			Text:         []string{"n=", ""},
+			Replacements: []lxn.Replacement{{Key: "n", TextPos: 1, Type: lxn.NumberReplacement}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loc := newLocale(lxn.Locale{
+		ID:            "ar-EG",
+		DecimalFormat: lxn.NumberFormat{Symbols: lxn.Symbols{Zero: '٠'}},
+	})
+	dic, err := NewDictionary(loc, cat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	latn, err := loc.WithExtensions(MustParseTag("ar-EG-u-nu-latn"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ldic, err := dic.WithLocale(latn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := Context{"n": Int(42)}
+	if got := ldic.Translate("", "count", ctx); got != "n=42" {
+		t.Errorf("unexpected translation with derived locale: %q", got)
+	}
+	if got := dic.Translate("", "count", ctx); got != "n=٤٢" {
+		t.Errorf("unexpected translation with original locale: %q", got)
+	}
+
+	if _, err := dic.WithLocale(newLocale(lxn.Locale{ID: "ar-SA"})); err == nil {
+		t.Errorf("expected error for mismatching locale")
+	}
+}
@@ -0,0 +1,89 @@
+package lxn
+
+import (
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// FormatNumber formats a number with the locale's decimal format. Variables which
+// are not numeric are formatted with their String method.
+func (l *Locale) FormatNumber(v Variable) string {
+	var w writer
+	l.writeNumber(&w, v)
+	return w.String()
+}
+
+// AppendNumber appends the number formatted with the locale's decimal format to
+// dst and returns the extended buffer.
+func (l *Locale) AppendNumber(dst []byte, v Variable) []byte {
+	w := writer{buf: dst}
+	l.writeNumber(&w, v)
+	return w.buf
+}
+
+// FormatPercent formats a percent value with the locale's percent format. The
+// value is scaled if the locale has percent scaling enabled (see
+// WithPercentScaling). Variables which are not numeric are formatted with their
+// String method.
+func (l *Locale) FormatPercent(v Variable) string {
+	var w writer
+	l.writePercent(&w, v)
+	return w.String()
+}
+
+// AppendPercent appends the percent value formatted with the locale's percent
+// format to dst and returns the extended buffer.
+func (l *Locale) AppendPercent(dst []byte, v Variable) []byte {
+	w := writer{buf: dst}
+	l.writePercent(&w, v)
+	return w.buf
+}
+
+// FormatMoney formats an amount of money with the locale's money format. The
+// currency is an ISO 4217 currency code. If v is a Money variable with its own
+// currency, the currency argument will be ignored. Variables which are not
+// numeric are formatted with their String method.
+func (l *Locale) FormatMoney(v Variable, currency string) string {
+	var w writer
+	l.writeMoney(&w, v, currency)
+	return w.String()
+}
+
+// AppendMoney appends the amount of money formatted with the locale's money
+// format to dst and returns the extended buffer.
+func (l *Locale) AppendMoney(dst []byte, v Variable, currency string) []byte {
+	w := writer{buf: dst}
+	l.writeMoney(&w, v, currency)
+	return w.buf
+}
+
+func (l *Locale) writeNumber(w *writer, v Variable) {
+	if num, isNum := v.(number); isNum {
+		num.format(w, &l.loc.DecimalFormat, noCurrency)
+	} else if v != nil {
+		w.WriteString(v.String())
+	}
+}
+
+func (l *Locale) writePercent(w *writer, v Variable) {
+	if num, isNum := v.(number); isNum {
+		num, nf := percentNumber(num, &l.loc.PercentFormat, l.percentScale(lxn.PercentScaleDefault))
+		num.format(w, nf, noCurrency)
+	} else if v != nil {
+		w.WriteString(v.String())
+	}
+}
+
+func (l *Locale) writeMoney(w *writer, v Variable, currency string) {
+	if money, isMoney := v.(Money); isMoney {
+		if money.Currency != "" {
+			currency = money.Currency
+		}
+		v = money.Amount
+	}
+	if num, isNum := v.(number); isNum {
+		nf, symbol, num := moneyFormat(num, &l.loc, currency, lxn.CurrencySymbol, lxn.CurrencyStandard, nil)
+		num.format(w, &nf, symbol)
+	} else if v != nil {
+		w.WriteString(v.String())
+	}
+}
@@ -0,0 +1,87 @@
+package lxn
+
+import (
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestLocaleFormat(t *testing.T) {
+	symb := lxn.Symbols{Zero: '0', Decimal: ",", Group: ".", Minus: "-", Percent: "%"}
+	loc := newLocale(lxn.Locale{
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  symb,
+			NegativePrefix:           "-",
+			MaxFractionDigits:        3,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		PercentFormat: lxn.NumberFormat{
+			Symbols:        symb,
+			PositiveSuffix: " %",
+			NegativePrefix: "-",
+			NegativeSuffix: " %",
+		},
+		MoneyFormat: lxn.NumberFormat{
+			Symbols:                  symb,
+			PositiveSuffix:           " ¤",
+			NegativePrefix:           "-",
+			NegativeSuffix:           " ¤",
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		Currencies: map[string]lxn.Currency{
+			"EUR": {Symbol: "€", FractionDigits: 2},
+		},
+	})
+
+	tests := []struct {
+		format   func(Variable) string
+		appendTo func([]byte, Variable) []byte
+		value    Variable
+		expected string
+	}{
+		{format: loc.FormatNumber, appendTo: loc.AppendNumber, value: Int(-1234567), expected: "-1.234.567"},
+		{format: loc.FormatNumber, appendTo: loc.AppendNumber, value: Float(3.14159), expected: "3,142"},
+		{format: loc.FormatNumber, appendTo: loc.AppendNumber, value: String("n/a"), expected: "n/a"},
+		{format: loc.FormatNumber, appendTo: loc.AppendNumber, value: nil, expected: ""},
+		{format: loc.FormatPercent, appendTo: loc.AppendPercent, value: nil, expected: ""},
+		{format: loc.FormatPercent, appendTo: loc.AppendPercent, value: Int(25), expected: "25 %"},
+		{format: loc.WithPercentScaling().FormatPercent, appendTo: loc.WithPercentScaling().AppendPercent, value: Float(0.25), expected: "25 %"},
+		{
+			format:   func(v Variable) string { return loc.FormatMoney(v, "EUR") },
+			appendTo: func(dst []byte, v Variable) []byte { return loc.AppendMoney(dst, v, "EUR") },
+			value:    Float(1234.5),
+			expected: "1.234,50 €",
+		},
+		{
+			format:   func(v Variable) string { return loc.FormatMoney(v, "EUR") },
+			appendTo: func(dst []byte, v Variable) []byte { return loc.AppendMoney(dst, v, "EUR") },
+			value:    Money{Amount: Int(-7), Currency: "USD"},
+			expected: "-7,00 USD",
+		},
+		{
+			format:   func(v Variable) string { return loc.FormatMoney(v, "EUR") },
+			appendTo: func(dst []byte, v Variable) []byte { return loc.AppendMoney(dst, v, "EUR") },
+			value:    nil,
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.format(test.value); got != test.expected {
+			t.Errorf("unexpected format for %q: %q", test.expected, got)
+		}
+		if got := string(test.appendTo([]byte("x="), test.value)); got != "x="+test.expected {
+			t.Errorf("unexpected append for %q: %q", test.expected, got)
+		}
+	}
+
+	// The Append functions write into the caller's buffer.
+	buf := make([]byte, 0, 64)
+	for _, test := range tests {
+		if got := test.appendTo(buf, Int(1234)); &got[0] != &buf[:1][0] {
+			t.Errorf("unexpected reallocation for %q", test.expected)
+		}
+	}
+}
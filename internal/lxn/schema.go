@@ -167,18 +167,22 @@ func (o *Dictionary) DecodeMsgpack(r *msgpack.Reader) error {
 
 // Symbols holds all the symbols that are used to format a number in a specific locale.
 type Symbols struct {
-	Decimal string
-	Group   string
-	Percent string
-	Minus   string
-	Inf     string
-	Nan     string
-	Zero    uint32
+	Decimal                string
+	Group                  string
+	Percent                string
+	Minus                  string
+	Inf                    string
+	Nan                    string
+	Zero                   uint32
+	Exponential            string
+	SuperscriptingExponent string
+	Plus                   string
+	PerMille               string
 }
 
 // EncodeMsgpack implements the Encoder interface for Symbols.
 func (o Symbols) EncodeMsgpack(w *msgpack.Writer) (err error) {
-	if err = w.WriteMapHeader(7); err != nil {
+	if err = w.WriteMapHeader(11); err != nil {
 		return err
 	}
 	// Decimal
@@ -230,6 +234,34 @@ func (o Symbols) EncodeMsgpack(w *msgpack.Writer) (err error) {
 	if err = w.WriteUint32(o.Zero); err != nil {
 		return err
 	}
+	// Exponential
+	if err = w.WriteInt64(8); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Exponential); err != nil {
+		return err
+	}
+	// SuperscriptingExponent
+	if err = w.WriteInt64(9); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.SuperscriptingExponent); err != nil {
+		return err
+	}
+	// Plus
+	if err = w.WriteInt64(10); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Plus); err != nil {
+		return err
+	}
+	// PerMille
+	if err = w.WriteInt64(11); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.PerMille); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -273,6 +305,22 @@ func (o *Symbols) DecodeMsgpack(r *msgpack.Reader) error {
 			if o.Zero, err = r.ReadUint32(); err != nil {
 				return err
 			}
+		case 8: // Exponential
+			if o.Exponential, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 9: // SuperscriptingExponent
+			if o.SuperscriptingExponent, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 10: // Plus
+			if o.Plus, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 11: // PerMille
+			if o.PerMille, err = r.ReadString(); err != nil {
+				return err
+			}
 		default:
 			if err := r.Skip(); err != nil {
 				return err
@@ -295,11 +343,16 @@ type NumberFormat struct {
 	PrimaryIntegerGrouping   int
 	SecondaryIntegerGrouping int
 	FractionGrouping         int
+	RoundingMode             RoundingMode
+	MinSignificantDigits     int
+	MaxSignificantDigits     int
+	SignDisplay              SignDisplay
+	MinGroupingDigits        int
 }
 
 // EncodeMsgpack implements the Encoder interface for NumberFormat.
 func (o NumberFormat) EncodeMsgpack(w *msgpack.Writer) (err error) {
-	if err = w.WriteMapHeader(11); err != nil {
+	if err = w.WriteMapHeader(16); err != nil {
 		return err
 	}
 	// Symbols
@@ -379,6 +432,41 @@ func (o NumberFormat) EncodeMsgpack(w *msgpack.Writer) (err error) {
 	if err = w.WriteInt(o.FractionGrouping); err != nil {
 		return err
 	}
+	// RoundingMode
+	if err = w.WriteInt64(12); err != nil {
+		return err
+	}
+	if err = o.RoundingMode.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// MinSignificantDigits
+	if err = w.WriteInt64(13); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.MinSignificantDigits); err != nil {
+		return err
+	}
+	// MaxSignificantDigits
+	if err = w.WriteInt64(14); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.MaxSignificantDigits); err != nil {
+		return err
+	}
+	// SignDisplay
+	if err = w.WriteInt64(15); err != nil {
+		return err
+	}
+	if err = o.SignDisplay.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// MinGroupingDigits
+	if err = w.WriteInt64(16); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.MinGroupingDigits); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -438,6 +526,26 @@ func (o *NumberFormat) DecodeMsgpack(r *msgpack.Reader) error {
 			if o.FractionGrouping, err = r.ReadInt(); err != nil {
 				return err
 			}
+		case 12: // RoundingMode
+			if err = o.RoundingMode.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 13: // MinSignificantDigits
+			if o.MinSignificantDigits, err = r.ReadInt(); err != nil {
+				return err
+			}
+		case 14: // MaxSignificantDigits
+			if o.MaxSignificantDigits, err = r.ReadInt(); err != nil {
+				return err
+			}
+		case 15: // SignDisplay
+			if err = o.SignDisplay.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 16: // MinGroupingDigits
+			if o.MinGroupingDigits, err = r.ReadInt(); err != nil {
+				return err
+			}
 		default:
 			if err := r.Skip(); err != nil {
 				return err
@@ -784,79 +892,61 @@ func (o *Plural) DecodeMsgpack(r *msgpack.Reader) error {
 	return nil
 }
 
-// Locale holds the data which is necessary to format data in a region
-// specific format.
-type Locale struct {
-	ID              string
-	DecimalFormat   NumberFormat
-	MoneyFormat     NumberFormat
-	PercentFormat   NumberFormat
-	CardinalPlurals []Plural
-	OrdinalPlurals  []Plural
+// CalendarNames holds the localized names for a calendar field (e.g. months or
+// weekdays) in different widths. The entries are ordered by the field's natural
+// order, i.e. months start with January and weekdays start with Sunday.
+type CalendarNames struct {
+	Abbreviated []string
+	Wide        []string
+	Narrow      []string
 }
 
-// EncodeMsgpack implements the Encoder interface for Locale.
-func (o Locale) EncodeMsgpack(w *msgpack.Writer) (err error) {
-	if err = w.WriteMapHeader(6); err != nil {
+// EncodeMsgpack implements the Encoder interface for CalendarNames.
+func (o CalendarNames) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(3); err != nil {
 		return err
 	}
-	// ID
+	// Abbreviated
 	if err = w.WriteInt64(1); err != nil {
 		return err
 	}
-	if err = w.WriteString(o.ID); err != nil {
-		return err
-	}
-	// DecimalFormat
-	if err = w.WriteInt64(2); err != nil {
-		return err
-	}
-	if err = o.DecimalFormat.EncodeMsgpack(w); err != nil {
-		return err
-	}
-	// MoneyFormat
-	if err = w.WriteInt64(3); err != nil {
-		return err
-	}
-	if err = o.MoneyFormat.EncodeMsgpack(w); err != nil {
-		return err
-	}
-	// PercentFormat
-	if err = w.WriteInt64(4); err != nil {
+	if err = w.WriteArrayHeader(len(o.Abbreviated)); err != nil {
 		return err
 	}
-	if err = o.PercentFormat.EncodeMsgpack(w); err != nil {
-		return err
+	for _, e := range o.Abbreviated {
+		if err = w.WriteString(e); err != nil {
+			return err
+		}
 	}
-	// CardinalPlurals
-	if err = w.WriteInt64(5); err != nil {
+	// Wide
+	if err = w.WriteInt64(2); err != nil {
 		return err
 	}
-	if err = w.WriteArrayHeader(len(o.CardinalPlurals)); err != nil {
+	if err = w.WriteArrayHeader(len(o.Wide)); err != nil {
 		return err
 	}
-	for _, e := range o.CardinalPlurals {
-		if err = e.EncodeMsgpack(w); err != nil {
+	for _, e := range o.Wide {
+		if err = w.WriteString(e); err != nil {
 			return err
 		}
 	}
-	// OrdinalPlurals
-	if err = w.WriteInt64(6); err != nil {
+	// Narrow
+	if err = w.WriteInt64(3); err != nil {
 		return err
 	}
-	if err = w.WriteArrayHeader(len(o.OrdinalPlurals)); err != nil {
+	if err = w.WriteArrayHeader(len(o.Narrow)); err != nil {
 		return err
 	}
-	for _, e := range o.OrdinalPlurals {
-		if err = e.EncodeMsgpack(w); err != nil {
+	for _, e := range o.Narrow {
+		if err = w.WriteString(e); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// DecodeMsgpack implements the Decoder interface for Locale.
-func (o *Locale) DecodeMsgpack(r *msgpack.Reader) error {
+// DecodeMsgpack implements the Decoder interface for CalendarNames.
+func (o *CalendarNames) DecodeMsgpack(r *msgpack.Reader) error {
 	n, err := r.ReadMapHeader()
 	if err != nil {
 		return err
@@ -867,49 +957,48 @@ func (o *Locale) DecodeMsgpack(r *msgpack.Reader) error {
 			return err
 		}
 		switch ord {
-		case 1: // ID
-			if o.ID, err = r.ReadString(); err != nil {
-				return err
-			}
-		case 2: // DecimalFormat
-			if err = o.DecimalFormat.DecodeMsgpack(r); err != nil {
+		case 1: // Abbreviated
+			oAbbreviatedLen, err := r.ReadArrayHeader()
+			if err != nil {
 				return err
 			}
-		case 3: // MoneyFormat
-			if err = o.MoneyFormat.DecodeMsgpack(r); err != nil {
-				return err
+			if cap(o.Abbreviated) < oAbbreviatedLen {
+				o.Abbreviated = make([]string, oAbbreviatedLen)
+			} else {
+				o.Abbreviated = o.Abbreviated[:oAbbreviatedLen]
 			}
-		case 4: // PercentFormat
-			if err = o.PercentFormat.DecodeMsgpack(r); err != nil {
-				return err
+			for i := 0; i < oAbbreviatedLen; i++ {
+				if o.Abbreviated[i], err = r.ReadString(); err != nil {
+					return err
+				}
 			}
-		case 5: // CardinalPlurals
-			oCardinalPluralsLen, err := r.ReadArrayHeader()
+		case 2: // Wide
+			oWideLen, err := r.ReadArrayHeader()
 			if err != nil {
 				return err
 			}
-			if cap(o.CardinalPlurals) < oCardinalPluralsLen {
-				o.CardinalPlurals = make([]Plural, oCardinalPluralsLen)
+			if cap(o.Wide) < oWideLen {
+				o.Wide = make([]string, oWideLen)
 			} else {
-				o.CardinalPlurals = o.CardinalPlurals[:oCardinalPluralsLen]
+				o.Wide = o.Wide[:oWideLen]
 			}
-			for i := 0; i < oCardinalPluralsLen; i++ {
-				if err = o.CardinalPlurals[i].DecodeMsgpack(r); err != nil {
+			for i := 0; i < oWideLen; i++ {
+				if o.Wide[i], err = r.ReadString(); err != nil {
 					return err
 				}
 			}
-		case 6: // OrdinalPlurals
-			oOrdinalPluralsLen, err := r.ReadArrayHeader()
+		case 3: // Narrow
+			oNarrowLen, err := r.ReadArrayHeader()
 			if err != nil {
 				return err
 			}
-			if cap(o.OrdinalPlurals) < oOrdinalPluralsLen {
-				o.OrdinalPlurals = make([]Plural, oOrdinalPluralsLen)
+			if cap(o.Narrow) < oNarrowLen {
+				o.Narrow = make([]string, oNarrowLen)
 			} else {
-				o.OrdinalPlurals = o.OrdinalPlurals[:oOrdinalPluralsLen]
+				o.Narrow = o.Narrow[:oNarrowLen]
 			}
-			for i := 0; i < oOrdinalPluralsLen; i++ {
-				if err = o.OrdinalPlurals[i].DecodeMsgpack(r); err != nil {
+			for i := 0; i < oNarrowLen; i++ {
+				if o.Narrow[i], err = r.ReadString(); err != nil {
 					return err
 				}
 			}
@@ -922,65 +1011,56 @@ func (o *Locale) DecodeMsgpack(r *msgpack.Reader) error {
 	return nil
 }
 
-// Message holds the data for a single message. Each message consists of
-// a list of fragments which has to be concatenated to receive the final
-// message text. If the message does not contain any replacement variables,
-// there will only be a single string fragment.
-type Message struct {
-	Section      string
-	Key          string
-	Text         []string
-	Replacements []Replacement
+// DateTimePatterns holds the patterns for each format length of a date, a time,
+// or a combined date and time. The patterns for combined dates and times contain
+// the placeholders {1} for the date and {0} for the time.
+//
+// https://unicode.org/reports/tr35/tr35-dates.html#Date_Field_Symbol_Table
+type DateTimePatterns struct {
+	Full   string
+	Long   string
+	Medium string
+	Short  string
 }
 
-// EncodeMsgpack implements the Encoder interface for Message.
-func (o Message) EncodeMsgpack(w *msgpack.Writer) (err error) {
+// EncodeMsgpack implements the Encoder interface for DateTimePatterns.
+func (o DateTimePatterns) EncodeMsgpack(w *msgpack.Writer) (err error) {
 	if err = w.WriteMapHeader(4); err != nil {
 		return err
 	}
-	// Section
+	// Full
 	if err = w.WriteInt64(1); err != nil {
 		return err
 	}
-	if err = w.WriteString(o.Section); err != nil {
+	if err = w.WriteString(o.Full); err != nil {
 		return err
 	}
-	// Key
+	// Long
 	if err = w.WriteInt64(2); err != nil {
 		return err
 	}
-	if err = w.WriteString(o.Key); err != nil {
+	if err = w.WriteString(o.Long); err != nil {
 		return err
 	}
-	// Text
+	// Medium
 	if err = w.WriteInt64(3); err != nil {
 		return err
 	}
-	if err = w.WriteArrayHeader(len(o.Text)); err != nil {
+	if err = w.WriteString(o.Medium); err != nil {
 		return err
 	}
-	for _, e := range o.Text {
-		if err = w.WriteString(e); err != nil {
-			return err
-		}
-	}
-	// Replacements
+	// Short
 	if err = w.WriteInt64(4); err != nil {
 		return err
 	}
-	if err = w.WriteArrayHeader(len(o.Replacements)); err != nil {
+	if err = w.WriteString(o.Short); err != nil {
 		return err
 	}
-	for _, e := range o.Replacements {
-		if err = e.EncodeMsgpack(w); err != nil {
-			return err
-		}
-	}
 	return nil
 }
 
-// DecodeMsgpack implements the Decoder interface for Message.
-func (o *Message) DecodeMsgpack(r *msgpack.Reader) error {
+// DecodeMsgpack implements the Decoder interface for DateTimePatterns.
+func (o *DateTimePatterns) DecodeMsgpack(r *msgpack.Reader) error {
 	n, err := r.ReadMapHeader()
 	if err != nil {
 		return err
@@ -991,44 +1071,22 @@ func (o *Message) DecodeMsgpack(r *msgpack.Reader) error {
 			return err
 		}
 		switch ord {
-		case 1: // Section
-			if o.Section, err = r.ReadString(); err != nil {
+		case 1: // Full
+			if o.Full, err = r.ReadString(); err != nil {
 				return err
 			}
-		case 2: // Key
-			if o.Key, err = r.ReadString(); err != nil {
+		case 2: // Long
+			if o.Long, err = r.ReadString(); err != nil {
 				return err
 			}
-		case 3: // Text
-			oTextLen, err := r.ReadArrayHeader()
-			if err != nil {
+		case 3: // Medium
+			if o.Medium, err = r.ReadString(); err != nil {
 				return err
 			}
-			if cap(o.Text) < oTextLen {
-				o.Text = make([]string, oTextLen)
-			} else {
-				o.Text = o.Text[:oTextLen]
-			}
-			for i := 0; i < oTextLen; i++ {
-				if o.Text[i], err = r.ReadString(); err != nil {
-					return err
-				}
-			}
-		case 4: // Replacements
-			oReplacementsLen, err := r.ReadArrayHeader()
-			if err != nil {
+		case 4: // Short
+			if o.Short, err = r.ReadString(); err != nil {
 				return err
 			}
-			if cap(o.Replacements) < oReplacementsLen {
-				o.Replacements = make([]Replacement, oReplacementsLen)
-			} else {
-				o.Replacements = o.Replacements[:oReplacementsLen]
-			}
-			for i := 0; i < oReplacementsLen; i++ {
-				if err = o.Replacements[i].DecodeMsgpack(r); err != nil {
-					return err
-				}
-			}
 		default:
 			if err := r.Skip(); err != nil {
 				return err
@@ -1038,54 +1096,94 @@ func (o *Message) DecodeMsgpack(r *msgpack.Reader) error {
 	return nil
 }
 
-// Replacement describes a variable piece of text in a message which will be replaced
-// during runtime. The key defines the variable's name which will be passed in. The type
-// contains more details about the particular replacement.
-type Replacement struct {
-	Key     string
-	TextPos int
-	Type    ReplacementType
-	Details ReplacementDetails
+// Calendar holds all the information to format dates and times of the gregorian
+// calendar in a specific locale. The available formats map a skeleton (e.g. "yMMMd")
+// to the locale specific pattern (e.g. "MMM d, y").
+type Calendar struct {
+	Months           CalendarNames
+	Weekdays         CalendarNames
+	DayPeriods       CalendarNames
+	Eras             CalendarNames
+	DateFormats      DateTimePatterns
+	TimeFormats      DateTimePatterns
+	DateTimeFormats  DateTimePatterns
+	AvailableFormats map[string]string
 }
 
-// EncodeMsgpack implements the Encoder interface for Replacement.
-func (o Replacement) EncodeMsgpack(w *msgpack.Writer) (err error) {
-	if err = w.WriteMapHeader(4); err != nil {
+// EncodeMsgpack implements the Encoder interface for Calendar.
+func (o Calendar) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(8); err != nil {
 		return err
 	}
-	// Key
+	// Months
 	if err = w.WriteInt64(1); err != nil {
 		return err
 	}
-	if err = w.WriteString(o.Key); err != nil {
+	if err = o.Months.EncodeMsgpack(w); err != nil {
 		return err
 	}
-	// TextPos
+	// Weekdays
 	if err = w.WriteInt64(2); err != nil {
 		return err
 	}
-	if err = w.WriteInt(o.TextPos); err != nil {
+	if err = o.Weekdays.EncodeMsgpack(w); err != nil {
 		return err
 	}
-	// Type
+	// DayPeriods
 	if err = w.WriteInt64(3); err != nil {
 		return err
 	}
-	if err = o.Type.EncodeMsgpack(w); err != nil {
+	if err = o.DayPeriods.EncodeMsgpack(w); err != nil {
 		return err
 	}
-	// Details
+	// Eras
 	if err = w.WriteInt64(4); err != nil {
 		return err
 	}
-	if err = o.Details.EncodeMsgpack(w); err != nil {
+	if err = o.Eras.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// DateFormats
+	if err = w.WriteInt64(5); err != nil {
+		return err
+	}
+	if err = o.DateFormats.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// TimeFormats
+	if err = w.WriteInt64(6); err != nil {
+		return err
+	}
+	if err = o.TimeFormats.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// DateTimeFormats
+	if err = w.WriteInt64(7); err != nil {
+		return err
+	}
+	if err = o.DateTimeFormats.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// AvailableFormats
+	if err = w.WriteInt64(8); err != nil {
 		return err
 	}
+	if err = w.WriteMapHeader(len(o.AvailableFormats)); err != nil {
+		return err
+	}
+	for k, v := range o.AvailableFormats {
+		if err = w.WriteString(k); err != nil {
+			return err
+		}
+		if err = w.WriteString(v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-// DecodeMsgpack implements the Decoder interface for Replacement.
-func (o *Replacement) DecodeMsgpack(r *msgpack.Reader) error {
+// DecodeMsgpack implements the Decoder interface for Calendar.
+func (o *Calendar) DecodeMsgpack(r *msgpack.Reader) error {
 	n, err := r.ReadMapHeader()
 	if err != nil {
 		return err
@@ -1096,24 +1194,735 @@ func (o *Replacement) DecodeMsgpack(r *msgpack.Reader) error {
 			return err
 		}
 		switch ord {
-		case 1: // Key
-			if o.Key, err = r.ReadString(); err != nil {
+		case 1: // Months
+			if err = o.Months.DecodeMsgpack(r); err != nil {
 				return err
 			}
-		case 2: // TextPos
-			if o.TextPos, err = r.ReadInt(); err != nil {
+		case 2: // Weekdays
+			if err = o.Weekdays.DecodeMsgpack(r); err != nil {
 				return err
 			}
-		case 3: // Type
-			if err = o.Type.DecodeMsgpack(r); err != nil {
+		case 3: // DayPeriods
+			if err = o.DayPeriods.DecodeMsgpack(r); err != nil {
 				return err
 			}
-		case 4: // Details
-			if err = o.Details.DecodeMsgpack(r); err != nil {
+		case 4: // Eras
+			if err = o.Eras.DecodeMsgpack(r); err != nil {
 				return err
 			}
-		default:
-			if err := r.Skip(); err != nil {
+		case 5: // DateFormats
+			if err = o.DateFormats.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 6: // TimeFormats
+			if err = o.TimeFormats.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 7: // DateTimeFormats
+			if err = o.DateTimeFormats.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 8: // AvailableFormats
+			oAvailableFormatsLen, err := r.ReadMapHeader()
+			if err != nil {
+				return err
+			}
+			if o.AvailableFormats == nil {
+				o.AvailableFormats = make(map[string]string, oAvailableFormatsLen)
+			}
+			for i := 0; i < oAvailableFormatsLen; i++ {
+				var k string
+				if k, err = r.ReadString(); err != nil {
+					return err
+				}
+				var v string
+				if v, err = r.ReadString(); err != nil {
+					return err
+				}
+				o.AvailableFormats[k] = v
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Currency holds the locale specific display data for a single currency and the
+// currency's fraction data. The rounding increment is given in units of the last
+// fraction digit, e.g. an increment of 5 with two fraction digits rounds to 0.05.
+// A zero increment means that no rounding to an increment is required.
+type Currency struct {
+	Symbol            string
+	NarrowSymbol      string
+	FractionDigits    int
+	RoundingIncrement int
+}
+
+// EncodeMsgpack implements the Encoder interface for Currency.
+func (o Currency) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(4); err != nil {
+		return err
+	}
+	// Symbol
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Symbol); err != nil {
+		return err
+	}
+	// NarrowSymbol
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.NarrowSymbol); err != nil {
+		return err
+	}
+	// FractionDigits
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.FractionDigits); err != nil {
+		return err
+	}
+	// RoundingIncrement
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.RoundingIncrement); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for Currency.
+func (o *Currency) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // Symbol
+			if o.Symbol, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 2: // NarrowSymbol
+			if o.NarrowSymbol, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 3: // FractionDigits
+			if o.FractionDigits, err = r.ReadInt(); err != nil {
+				return err
+			}
+		case 4: // RoundingIncrement
+			if o.RoundingIncrement, err = r.ReadInt(); err != nil {
+				return err
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CompactPattern holds a single pattern to format a number in a compact form
+// (e.g. "1.2K"). The pattern applies to numbers with the given magnitude, i.e.
+// to numbers n with 10^Magnitude <= n < 10^(Magnitude+1), and whose plural form
+// matches the category. Before formatting, the number is divided by 10^Exponent.
+// An exponent of zero means that the number will not be compacted.
+type CompactPattern struct {
+	Magnitude int
+	Category  PluralCategory
+	Exponent  int
+	Prefix    string
+	Suffix    string
+}
+
+// EncodeMsgpack implements the Encoder interface for CompactPattern.
+func (o CompactPattern) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(5); err != nil {
+		return err
+	}
+	// Magnitude
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.Magnitude); err != nil {
+		return err
+	}
+	// Category
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = o.Category.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// Exponent
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.Exponent); err != nil {
+		return err
+	}
+	// Prefix
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Prefix); err != nil {
+		return err
+	}
+	// Suffix
+	if err = w.WriteInt64(5); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Suffix); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for CompactPattern.
+func (o *CompactPattern) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // Magnitude
+			if o.Magnitude, err = r.ReadInt(); err != nil {
+				return err
+			}
+		case 2: // Category
+			if err = o.Category.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 3: // Exponent
+			if o.Exponent, err = r.ReadInt(); err != nil {
+				return err
+			}
+		case 4: // Prefix
+			if o.Prefix, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 5: // Suffix
+			if o.Suffix, err = r.ReadString(); err != nil {
+				return err
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CompactFormat holds the patterns to format numbers in a compact form. The short
+// patterns use abbreviations (e.g. "1.2K"), whereas the long patterns use the
+// full words (e.g. "1.2 thousand"). The patterns are sorted by magnitude.
+type CompactFormat struct {
+	Short []CompactPattern
+	Long  []CompactPattern
+}
+
+// EncodeMsgpack implements the Encoder interface for CompactFormat.
+func (o CompactFormat) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(2); err != nil {
+		return err
+	}
+	// Short
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = w.WriteArrayHeader(len(o.Short)); err != nil {
+		return err
+	}
+	for _, e := range o.Short {
+		if err = e.EncodeMsgpack(w); err != nil {
+			return err
+		}
+	}
+	// Long
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = w.WriteArrayHeader(len(o.Long)); err != nil {
+		return err
+	}
+	for _, e := range o.Long {
+		if err = e.EncodeMsgpack(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for CompactFormat.
+func (o *CompactFormat) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // Short
+			oShortLen, err := r.ReadArrayHeader()
+			if err != nil {
+				return err
+			}
+			if cap(o.Short) < oShortLen {
+				o.Short = make([]CompactPattern, oShortLen)
+			} else {
+				o.Short = o.Short[:oShortLen]
+			}
+			for i := 0; i < oShortLen; i++ {
+				if err = o.Short[i].DecodeMsgpack(r); err != nil {
+					return err
+				}
+			}
+		case 2: // Long
+			oLongLen, err := r.ReadArrayHeader()
+			if err != nil {
+				return err
+			}
+			if cap(o.Long) < oLongLen {
+				o.Long = make([]CompactPattern, oLongLen)
+			} else {
+				o.Long = o.Long[:oLongLen]
+			}
+			for i := 0; i < oLongLen; i++ {
+				if err = o.Long[i].DecodeMsgpack(r); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Locale holds the data which is necessary to format data in a region
+// specific format.
+type Locale struct {
+	ID               string
+	DecimalFormat    NumberFormat
+	MoneyFormat      NumberFormat
+	PercentFormat    NumberFormat
+	CardinalPlurals  []Plural
+	OrdinalPlurals   []Plural
+	Calendar         Calendar
+	Currencies       map[string]Currency
+	CompactFormat    CompactFormat
+	AccountingFormat NumberFormat
+}
+
+// EncodeMsgpack implements the Encoder interface for Locale.
+func (o Locale) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(10); err != nil {
+		return err
+	}
+	// ID
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.ID); err != nil {
+		return err
+	}
+	// DecimalFormat
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = o.DecimalFormat.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// MoneyFormat
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = o.MoneyFormat.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// PercentFormat
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = o.PercentFormat.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// CardinalPlurals
+	if err = w.WriteInt64(5); err != nil {
+		return err
+	}
+	if err = w.WriteArrayHeader(len(o.CardinalPlurals)); err != nil {
+		return err
+	}
+	for _, e := range o.CardinalPlurals {
+		if err = e.EncodeMsgpack(w); err != nil {
+			return err
+		}
+	}
+	// OrdinalPlurals
+	if err = w.WriteInt64(6); err != nil {
+		return err
+	}
+	if err = w.WriteArrayHeader(len(o.OrdinalPlurals)); err != nil {
+		return err
+	}
+	for _, e := range o.OrdinalPlurals {
+		if err = e.EncodeMsgpack(w); err != nil {
+			return err
+		}
+	}
+	// Calendar
+	if err = w.WriteInt64(7); err != nil {
+		return err
+	}
+	if err = o.Calendar.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// Currencies
+	if err = w.WriteInt64(8); err != nil {
+		return err
+	}
+	if err = w.WriteMapHeader(len(o.Currencies)); err != nil {
+		return err
+	}
+	for k, v := range o.Currencies {
+		if err = w.WriteString(k); err != nil {
+			return err
+		}
+		if err = v.EncodeMsgpack(w); err != nil {
+			return err
+		}
+	}
+	// CompactFormat
+	if err = w.WriteInt64(9); err != nil {
+		return err
+	}
+	if err = o.CompactFormat.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// AccountingFormat
+	if err = w.WriteInt64(10); err != nil {
+		return err
+	}
+	if err = o.AccountingFormat.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for Locale.
+func (o *Locale) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // ID
+			if o.ID, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 2: // DecimalFormat
+			if err = o.DecimalFormat.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 3: // MoneyFormat
+			if err = o.MoneyFormat.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 4: // PercentFormat
+			if err = o.PercentFormat.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 5: // CardinalPlurals
+			oCardinalPluralsLen, err := r.ReadArrayHeader()
+			if err != nil {
+				return err
+			}
+			if cap(o.CardinalPlurals) < oCardinalPluralsLen {
+				o.CardinalPlurals = make([]Plural, oCardinalPluralsLen)
+			} else {
+				o.CardinalPlurals = o.CardinalPlurals[:oCardinalPluralsLen]
+			}
+			for i := 0; i < oCardinalPluralsLen; i++ {
+				if err = o.CardinalPlurals[i].DecodeMsgpack(r); err != nil {
+					return err
+				}
+			}
+		case 6: // OrdinalPlurals
+			oOrdinalPluralsLen, err := r.ReadArrayHeader()
+			if err != nil {
+				return err
+			}
+			if cap(o.OrdinalPlurals) < oOrdinalPluralsLen {
+				o.OrdinalPlurals = make([]Plural, oOrdinalPluralsLen)
+			} else {
+				o.OrdinalPlurals = o.OrdinalPlurals[:oOrdinalPluralsLen]
+			}
+			for i := 0; i < oOrdinalPluralsLen; i++ {
+				if err = o.OrdinalPlurals[i].DecodeMsgpack(r); err != nil {
+					return err
+				}
+			}
+		case 7: // Calendar
+			if err = o.Calendar.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 8: // Currencies
+			oCurrenciesLen, err := r.ReadMapHeader()
+			if err != nil {
+				return err
+			}
+			if o.Currencies == nil {
+				o.Currencies = make(map[string]Currency, oCurrenciesLen)
+			}
+			for i := 0; i < oCurrenciesLen; i++ {
+				var k string
+				if k, err = r.ReadString(); err != nil {
+					return err
+				}
+				var v Currency
+				if err = v.DecodeMsgpack(r); err != nil {
+					return err
+				}
+				o.Currencies[k] = v
+			}
+		case 9: // CompactFormat
+			if err = o.CompactFormat.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 10: // AccountingFormat
+			if err = o.AccountingFormat.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Message holds the data for a single message. Each message consists of
+// a list of fragments which has to be concatenated to receive the final
+// message text. If the message does not contain any replacement variables,
+// there will only be a single string fragment.
+type Message struct {
+	Section      string
+	Key          string
+	Text         []string
+	Replacements []Replacement
+}
+
+// EncodeMsgpack implements the Encoder interface for Message.
+func (o Message) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(4); err != nil {
+		return err
+	}
+	// Section
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Section); err != nil {
+		return err
+	}
+	// Key
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Key); err != nil {
+		return err
+	}
+	// Text
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = w.WriteArrayHeader(len(o.Text)); err != nil {
+		return err
+	}
+	for _, e := range o.Text {
+		if err = w.WriteString(e); err != nil {
+			return err
+		}
+	}
+	// Replacements
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = w.WriteArrayHeader(len(o.Replacements)); err != nil {
+		return err
+	}
+	for _, e := range o.Replacements {
+		if err = e.EncodeMsgpack(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for Message.
+func (o *Message) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // Section
+			if o.Section, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 2: // Key
+			if o.Key, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 3: // Text
+			oTextLen, err := r.ReadArrayHeader()
+			if err != nil {
+				return err
+			}
+			if cap(o.Text) < oTextLen {
+				o.Text = make([]string, oTextLen)
+			} else {
+				o.Text = o.Text[:oTextLen]
+			}
+			for i := 0; i < oTextLen; i++ {
+				if o.Text[i], err = r.ReadString(); err != nil {
+					return err
+				}
+			}
+		case 4: // Replacements
+			oReplacementsLen, err := r.ReadArrayHeader()
+			if err != nil {
+				return err
+			}
+			if cap(o.Replacements) < oReplacementsLen {
+				o.Replacements = make([]Replacement, oReplacementsLen)
+			} else {
+				o.Replacements = o.Replacements[:oReplacementsLen]
+			}
+			for i := 0; i < oReplacementsLen; i++ {
+				if err = o.Replacements[i].DecodeMsgpack(r); err != nil {
+					return err
+				}
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Replacement describes a variable piece of text in a message which will be replaced
+// during runtime. The key defines the variable's name which will be passed in. The type
+// contains more details about the particular replacement.
+type Replacement struct {
+	Key     string
+	TextPos int
+	Type    ReplacementType
+	Details ReplacementDetails
+}
+
+// EncodeMsgpack implements the Encoder interface for Replacement.
+func (o Replacement) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(4); err != nil {
+		return err
+	}
+	// Key
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Key); err != nil {
+		return err
+	}
+	// TextPos
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.TextPos); err != nil {
+		return err
+	}
+	// Type
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = o.Type.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// Details
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = o.Details.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for Replacement.
+func (o *Replacement) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // Key
+			if o.Key, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 2: // TextPos
+			if o.TextPos, err = r.ReadInt(); err != nil {
+				return err
+			}
+		case 3: // Type
+			if err = o.Type.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 4: // Details
+			if err = o.Details.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		default:
+			if err := r.Skip(); err != nil {
 				return err
 			}
 		}
@@ -1124,7 +1933,7 @@ func (o *Replacement) DecodeMsgpack(r *msgpack.Reader) error {
 // ReplacementDetails holds the details for particular replacements. The special
 // EmptyDetails branch indicates that there a no details for the replacement type.
 type ReplacementDetails struct {
-	Value interface{} // EmptyDetails, MoneyDetails, PluralDetails, or SelectDetails
+	Value interface{} // EmptyDetails, MoneyDetails, PluralDetails, SelectDetails, TimeDetails, or NumberDetails
 }
 
 // EncodeMsgpack implements the Encoder interface for ReplacementDetails.
@@ -1161,6 +1970,20 @@ func (o ReplacementDetails) EncodeMsgpack(w *msgpack.Writer) (err error) {
 		if err = v.EncodeMsgpack(w); err != nil {
 			return err
 		}
+	case TimeDetails:
+		if err = w.WriteInt64(5); err != nil {
+			return err
+		}
+		if err = v.EncodeMsgpack(w); err != nil {
+			return err
+		}
+	case NumberDetails:
+		if err = w.WriteInt64(6); err != nil {
+			return err
+		}
+		if err = v.EncodeMsgpack(w); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("invalid ReplacementDetails type %T", o.Value)
 	}
@@ -1189,14 +2012,26 @@ func (o *ReplacementDetails) DecodeMsgpack(r *msgpack.Reader) error {
 			return err
 		}
 		o.Value = v
-	case 3: // PluralDetails
-		var v PluralDetails
+	case 3: // PluralDetails
+		var v PluralDetails
+		if err = v.DecodeMsgpack(r); err != nil {
+			return err
+		}
+		o.Value = v
+	case 4: // SelectDetails
+		var v SelectDetails
+		if err = v.DecodeMsgpack(r); err != nil {
+			return err
+		}
+		o.Value = v
+	case 5: // TimeDetails
+		var v TimeDetails
 		if err = v.DecodeMsgpack(r); err != nil {
 			return err
 		}
 		o.Value = v
-	case 4: // SelectDetails
-		var v SelectDetails
+	case 6: // NumberDetails
+		var v NumberDetails
 		if err = v.DecodeMsgpack(r); err != nil {
 			return err
 		}
@@ -1219,6 +2054,7 @@ const (
 	MoneyReplacement   ReplacementType = 4
 	PluralReplacement  ReplacementType = 5
 	SelectReplacement  ReplacementType = 6
+	TimeReplacement    ReplacementType = 7
 )
 
 // EncodeMsgpack implements the Encoder interface for ReplacementType.
@@ -1294,14 +2130,22 @@ func (o *EmptyDetails) DecodeMsgpack(r *msgpack.Reader) error {
 	return nil
 }
 
-// MoneyDetails contains the replacement details for amounts of money.
+// MoneyDetails contains the replacement details for amounts of money. The
+// currency is the name of the variable which holds the ISO 4217 currency code.
+// The display field defines how the currency is represented in the formatted
+// amount. The style selects between the locale's money and accounting formats.
+// The skeleton is an optional ICU number skeleton which overrides the locale's
+// format for this replacement.
 type MoneyDetails struct {
 	Currency string
+	Display  CurrencyDisplay
+	Skeleton string
+	Style    CurrencyStyle
 }
 
 // EncodeMsgpack implements the Encoder interface for MoneyDetails.
 func (o MoneyDetails) EncodeMsgpack(w *msgpack.Writer) (err error) {
-	if err = w.WriteMapHeader(1); err != nil {
+	if err = w.WriteMapHeader(4); err != nil {
 		return err
 	}
 	// Currency
@@ -1311,6 +2155,27 @@ func (o MoneyDetails) EncodeMsgpack(w *msgpack.Writer) (err error) {
 	if err = w.WriteString(o.Currency); err != nil {
 		return err
 	}
+	// Display
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = o.Display.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// Skeleton
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Skeleton); err != nil {
+		return err
+	}
+	// Style
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = o.Style.EncodeMsgpack(w); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1330,6 +2195,18 @@ func (o *MoneyDetails) DecodeMsgpack(r *msgpack.Reader) error {
 			if o.Currency, err = r.ReadString(); err != nil {
 				return err
 			}
+		case 2: // Display
+			if err = o.Display.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 3: // Skeleton
+			if o.Skeleton, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 4: // Style
+			if err = o.Style.DecodeMsgpack(r); err != nil {
+				return err
+			}
 		default:
 			if err := r.Skip(); err != nil {
 				return err
@@ -1341,16 +2218,21 @@ func (o *MoneyDetails) DecodeMsgpack(r *msgpack.Reader) error {
 
 // PluralDetails contains the replacement details for plurals. Depending on the
 // variable, different text for each plural rule can be selected. It contains
-// the variants for the supported plural categories and custom overwrites.
+// the variants for the supported plural categories and custom overwrites. If
+// Percent is set, the variable is treated as a percent value and scaled with the
+// percent scale before the plural category is determined. Otherwise the percent
+// scale is ignored.
 type PluralDetails struct {
-	Type     PluralType
-	Variants map[PluralCategory]Message
-	Custom   map[int64]Message
+	Type         PluralType
+	Variants     map[PluralCategory]Message
+	Custom       map[int64]Message
+	PercentScale PercentScale
+	Percent      bool
 }
 
 // EncodeMsgpack implements the Encoder interface for PluralDetails.
 func (o PluralDetails) EncodeMsgpack(w *msgpack.Writer) (err error) {
-	if err = w.WriteMapHeader(3); err != nil {
+	if err = w.WriteMapHeader(5); err != nil {
 		return err
 	}
 	// Type
@@ -1390,6 +2272,20 @@ func (o PluralDetails) EncodeMsgpack(w *msgpack.Writer) (err error) {
 			return err
 		}
 	}
+	// PercentScale
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = o.PercentScale.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// Percent
+	if err = w.WriteInt64(5); err != nil {
+		return err
+	}
+	if err = w.WriteBool(o.Percent); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1447,6 +2343,14 @@ func (o *PluralDetails) DecodeMsgpack(r *msgpack.Reader) error {
 				}
 				o.Custom[k] = v
 			}
+		case 4: // PercentScale
+			if err = o.PercentScale.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 5: // Percent
+			if o.Percent, err = r.ReadBool(); err != nil {
+				return err
+			}
 		default:
 			if err := r.Skip(); err != nil {
 				return err
@@ -1537,3 +2441,377 @@ func (o *SelectDetails) DecodeMsgpack(r *msgpack.Reader) error {
 	}
 	return nil
 }
+
+// DateTimeStyle is an enumeration of the predefined format lengths for dates and
+// times.
+type DateTimeStyle int
+
+// Enumerators for DateTimeStyle.
+const (
+	DateTimeNone   DateTimeStyle = 0
+	DateTimeShort  DateTimeStyle = 1
+	DateTimeMedium DateTimeStyle = 2
+	DateTimeLong   DateTimeStyle = 3
+	DateTimeFull   DateTimeStyle = 4
+)
+
+// EncodeMsgpack implements the Encoder interface for DateTimeStyle.
+func (o DateTimeStyle) EncodeMsgpack(w *msgpack.Writer) error {
+	return w.WriteInt(int(o))
+}
+
+// DecodeMsgpack implements the Decoder interface for DateTimeStyle.
+func (o *DateTimeStyle) DecodeMsgpack(r *msgpack.Reader) error {
+	val, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	*o = DateTimeStyle(val)
+	return nil
+}
+
+// TimeDetails contains the replacement details for dates and times. Either the
+// skeleton is set, which describes the fields to be formatted, or the date style
+// and time style define the format length of the date part and time part. If a
+// style is DateTimeNone, the respective part will be omitted.
+type TimeDetails struct {
+	DateStyle DateTimeStyle
+	TimeStyle DateTimeStyle
+	Skeleton  string
+}
+
+// EncodeMsgpack implements the Encoder interface for TimeDetails.
+func (o TimeDetails) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(3); err != nil {
+		return err
+	}
+	// DateStyle
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = o.DateStyle.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// TimeStyle
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = o.TimeStyle.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// Skeleton
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Skeleton); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for TimeDetails.
+func (o *TimeDetails) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // DateStyle
+			if err = o.DateStyle.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 2: // TimeStyle
+			if err = o.TimeStyle.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 3: // Skeleton
+			if o.Skeleton, err = r.ReadString(); err != nil {
+				return err
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CurrencyDisplay is an enumeration of the representations for a currency
+// in a formatted amount of money.
+type CurrencyDisplay int
+
+// Enumerators for CurrencyDisplay.
+const (
+	CurrencySymbol       CurrencyDisplay = 0
+	CurrencyNarrowSymbol CurrencyDisplay = 1
+	CurrencyCode         CurrencyDisplay = 2
+)
+
+// EncodeMsgpack implements the Encoder interface for CurrencyDisplay.
+func (o CurrencyDisplay) EncodeMsgpack(w *msgpack.Writer) error {
+	return w.WriteInt(int(o))
+}
+
+// DecodeMsgpack implements the Decoder interface for CurrencyDisplay.
+func (o *CurrencyDisplay) DecodeMsgpack(r *msgpack.Reader) error {
+	val, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	*o = CurrencyDisplay(val)
+	return nil
+}
+
+// NumberStyle is an enumeration of the styles a number can be formatted with.
+type NumberStyle int
+
+// Enumerators for NumberStyle.
+const (
+	DecimalStyle      NumberStyle = 0
+	CompactShortStyle NumberStyle = 1
+	CompactLongStyle  NumberStyle = 2
+	ScientificStyle   NumberStyle = 3
+	EngineeringStyle  NumberStyle = 4
+)
+
+// EncodeMsgpack implements the Encoder interface for NumberStyle.
+func (o NumberStyle) EncodeMsgpack(w *msgpack.Writer) error {
+	return w.WriteInt(int(o))
+}
+
+// DecodeMsgpack implements the Decoder interface for NumberStyle.
+func (o *NumberStyle) DecodeMsgpack(r *msgpack.Reader) error {
+	val, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	*o = NumberStyle(val)
+	return nil
+}
+
+// NumberDetails contains the replacement details for numbers. A number replacement
+// without details is formatted with the decimal style.
+//
+// The mantissa digits define the maximum number of significant digits of the
+// mantissa for the scientific and engineering styles. If it is zero, the decimal
+// format's maximum fraction digits (plus one integer digit) will be used. If
+// SuperscriptExponent is set, the exponent will be written as a superscripted
+// power of ten (e.g. 1.2×10³) instead of using the exponential symbol (e.g. 1.2E3).
+//
+// The skeleton is an optional ICU number skeleton (e.g. ".00 group-off") which
+// overrides the locale's number format for this replacement. The percent scale
+// is used for percent replacements only.
+type NumberDetails struct {
+	Style               NumberStyle
+	MantissaDigits      int
+	SuperscriptExponent bool
+	Skeleton            string
+	PercentScale        PercentScale
+}
+
+// EncodeMsgpack implements the Encoder interface for NumberDetails.
+func (o NumberDetails) EncodeMsgpack(w *msgpack.Writer) (err error) {
+	if err = w.WriteMapHeader(5); err != nil {
+		return err
+	}
+	// Style
+	if err = w.WriteInt64(1); err != nil {
+		return err
+	}
+	if err = o.Style.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	// MantissaDigits
+	if err = w.WriteInt64(2); err != nil {
+		return err
+	}
+	if err = w.WriteInt(o.MantissaDigits); err != nil {
+		return err
+	}
+	// SuperscriptExponent
+	if err = w.WriteInt64(3); err != nil {
+		return err
+	}
+	if err = w.WriteBool(o.SuperscriptExponent); err != nil {
+		return err
+	}
+	// Skeleton
+	if err = w.WriteInt64(4); err != nil {
+		return err
+	}
+	if err = w.WriteString(o.Skeleton); err != nil {
+		return err
+	}
+	// PercentScale
+	if err = w.WriteInt64(5); err != nil {
+		return err
+	}
+	if err = o.PercentScale.EncodeMsgpack(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodeMsgpack implements the Decoder interface for NumberDetails.
+func (o *NumberDetails) DecodeMsgpack(r *msgpack.Reader) error {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		ord, err := r.ReadInt64()
+		if err != nil {
+			return err
+		}
+		switch ord {
+		case 1: // Style
+			if err = o.Style.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		case 2: // MantissaDigits
+			if o.MantissaDigits, err = r.ReadInt(); err != nil {
+				return err
+			}
+		case 3: // SuperscriptExponent
+			if o.SuperscriptExponent, err = r.ReadBool(); err != nil {
+				return err
+			}
+		case 4: // Skeleton
+			if o.Skeleton, err = r.ReadString(); err != nil {
+				return err
+			}
+		case 5: // PercentScale
+			if err = o.PercentScale.DecodeMsgpack(r); err != nil {
+				return err
+			}
+		default:
+			if err := r.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RoundingMode is an enumeration of the modes to round a number to the number of
+// fraction digits defined by the number format.
+//
+// https://unicode.org/reports/tr35/tr35-numbers.html#Rounding_Modes
+type RoundingMode int
+
+// Enumerators for RoundingMode.
+const (
+	RoundHalfEven RoundingMode = 0
+	RoundHalfUp   RoundingMode = 1
+	RoundHalfDown RoundingMode = 2
+	RoundCeiling  RoundingMode = 3
+	RoundFloor    RoundingMode = 4
+	RoundDown     RoundingMode = 5
+	RoundUp       RoundingMode = 6
+)
+
+// EncodeMsgpack implements the Encoder interface for RoundingMode.
+func (o RoundingMode) EncodeMsgpack(w *msgpack.Writer) error {
+	return w.WriteInt(int(o))
+}
+
+// DecodeMsgpack implements the Decoder interface for RoundingMode.
+func (o *RoundingMode) DecodeMsgpack(r *msgpack.Reader) error {
+	val, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	*o = RoundingMode(val)
+	return nil
+}
+
+// SignDisplay is an enumeration of the modes to display the sign of a number.
+// The auto mode displays the sign for negative numbers only, the always mode for
+// all numbers, the never mode for no number, and the except-zero mode for all
+// numbers except zero.
+type SignDisplay int
+
+// Enumerators for SignDisplay.
+const (
+	SignAuto       SignDisplay = 0
+	SignAlways     SignDisplay = 1
+	SignNever      SignDisplay = 2
+	SignExceptZero SignDisplay = 3
+)
+
+// EncodeMsgpack implements the Encoder interface for SignDisplay.
+func (o SignDisplay) EncodeMsgpack(w *msgpack.Writer) error {
+	return w.WriteInt(int(o))
+}
+
+// DecodeMsgpack implements the Decoder interface for SignDisplay.
+func (o *SignDisplay) DecodeMsgpack(r *msgpack.Reader) error {
+	val, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	*o = SignDisplay(val)
+	return nil
+}
+
+// CurrencyStyle is an enumeration of the styles to format amounts of money. The
+// accounting style uses the locale's accounting format, which usually wraps
+// negative amounts in parentheses.
+type CurrencyStyle int
+
+// Enumerators for CurrencyStyle.
+const (
+	CurrencyStandard   CurrencyStyle = 0
+	CurrencyAccounting CurrencyStyle = 1
+)
+
+// EncodeMsgpack implements the Encoder interface for CurrencyStyle.
+func (o CurrencyStyle) EncodeMsgpack(w *msgpack.Writer) error {
+	return w.WriteInt(int(o))
+}
+
+// DecodeMsgpack implements the Decoder interface for CurrencyStyle.
+func (o *CurrencyStyle) DecodeMsgpack(r *msgpack.Reader) error {
+	val, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	*o = CurrencyStyle(val)
+	return nil
+}
+
+// PercentScale is an enumeration of the ways to scale a percent value. With the
+// default scale, the dictionary's setting is used. The none scale formats the
+// value as is (i.e. 25 becomes "25%"), whereas the hundred scale treats the value
+// as a ratio (i.e. 0.25 becomes "25%"). The thousand scale treats the value as
+// a ratio, too, but formats it in per-mille (i.e. 0.025 becomes "25‰").
+type PercentScale int
+
+// Enumerators for PercentScale.
+const (
+	PercentScaleDefault  PercentScale = 0
+	PercentScaleNone     PercentScale = 1
+	PercentScaleHundred  PercentScale = 2
+	PercentScaleThousand PercentScale = 3
+)
+
+// EncodeMsgpack implements the Encoder interface for PercentScale.
+func (o PercentScale) EncodeMsgpack(w *msgpack.Writer) error {
+	return w.WriteInt(int(o))
+}
+
+// DecodeMsgpack implements the Decoder interface for PercentScale.
+func (o *PercentScale) DecodeMsgpack(r *msgpack.Reader) error {
+	val, err := r.ReadInt()
+	if err != nil {
+		return err
+	}
+	*o = PercentScale(val)
+	return nil
+}
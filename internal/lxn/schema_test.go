@@ -0,0 +1,125 @@
+package lxn
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestSchemaGenerated checks that the generated code matches the schema, i.e.
+// that schema.go was regenerated after changing schema.mprot and was not edited
+// by hand.
+func TestSchemaGenerated(t *testing.T) {
+	schema, err := readSchema("schema.mprot")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	src, err := os.ReadFile("schema.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	generated := generatedSchema(string(src))
+
+	for name, decl := range schema {
+		switch gen, has := generated[name]; {
+		case !has:
+			t.Errorf("%s %s is not generated", decl.kind, name)
+		case !reflect.DeepEqual(gen, decl):
+			t.Errorf("generated %s %s does not match the schema:\n%v\n%v", decl.kind, name, gen, decl)
+		}
+	}
+	for name, gen := range generated {
+		if _, has := schema[name]; !has {
+			t.Errorf("%s %s is not in the schema", gen.kind, name)
+		}
+	}
+}
+
+type schemaDecl struct {
+	kind    string // struct, union, or enum
+	entries []string
+}
+
+// readSchema reads the declarations of an mprot schema. Each entry is the entry's
+// line without comments and with normalized whitespace, e.g. "Key string 1".
+func readSchema(filename string) (map[string]schemaDecl, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decls := make(map[string]schemaDecl)
+	var (
+		name string
+		decl schemaDecl
+	)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line, _, _ := strings.Cut(s.Text(), "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case len(fields) == 3 && fields[2] == "{":
+			name, decl = fields[1], schemaDecl{kind: fields[0]}
+		case fields[0] == "}":
+			decls[name] = decl
+			name = ""
+		case name != "":
+			decl.entries = append(decl.entries, strings.Join(fields, " "))
+		}
+	}
+	return decls, s.Err()
+}
+
+var (
+	generatedType       = regexp.MustCompile(`(?m)^type (\w+) (struct \{\n((?:\t.*\n)*)\}|int)$`)
+	generatedOrdinal    = regexp.MustCompile(`// (\w+)\n\tif err = w\.WriteInt64\((\d+)\)`)
+	generatedBranch     = regexp.MustCompile(`case (\w+):\n\t\tif err = w\.WriteInt64\((\d+)\)`)
+	generatedEnumerator = regexp.MustCompile(`(?m)^\t(\w+) +(\w+) = (\d+)$`)
+)
+
+// generatedSchema reconstructs the schema declarations from the generated code.
+func generatedSchema(src string) map[string]schemaDecl {
+	decls := make(map[string]schemaDecl)
+	for _, m := range generatedType.FindAllStringSubmatch(src, -1) {
+		name := m[1]
+		if m[2] == "int" {
+			decl := schemaDecl{kind: "enum"}
+			for _, e := range generatedEnumerator.FindAllStringSubmatch(src, -1) {
+				if e[2] == name {
+					decl.entries = append(decl.entries, e[1]+" "+e[3])
+				}
+			}
+			decls[name] = decl
+			continue
+		}
+
+		encoder := src[strings.Index(src, "func (o "+name+") EncodeMsgpack"):]
+		encoder = encoder[:strings.Index(encoder, "\n}\n")]
+		if strings.HasPrefix(strings.TrimSpace(m[3]), "Value interface{}") {
+			decl := schemaDecl{kind: "union"}
+			for _, b := range generatedBranch.FindAllStringSubmatch(encoder, -1) {
+				decl.entries = append(decl.entries, b[1]+" "+b[2])
+			}
+			decls[name] = decl
+			continue
+		}
+
+		ordinals := make(map[string]string)
+		for _, o := range generatedOrdinal.FindAllStringSubmatch(encoder, -1) {
+			ordinals[o[1]] = o[2]
+		}
+		decl := schemaDecl{kind: "struct"}
+		for _, field := range strings.Split(strings.TrimSpace(m[3]), "\n") {
+			if f := strings.Fields(field); len(f) == 2 {
+				decl.entries = append(decl.entries, f[0]+" "+f[1]+" "+ordinals[f[0]])
+			}
+		}
+		decls[name] = decl
+	}
+	return decls
+}
@@ -0,0 +1,126 @@
+package lxn
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// LayeredDictionary is a view on a dictionary with additional override catalogs.
+// A message is looked up in the override layers first, starting with the most
+// recently added one, and then in the base dictionary. All messages are formatted
+// with the locale of the base dictionary.
+//
+// Layers can be added and removed at any time without touching the base
+// dictionary. A layered dictionary is safe for concurrent use.
+type LayeredDictionary struct {
+	base *Dictionary
+
+	mtx    sync.RWMutex
+	layers []layer // ordered by priority, the highest priority comes last
+}
+
+type layer struct {
+	name string
+	cat  *Catalog
+}
+
+// NewLayeredDictionary creates a layered dictionary without any override layers.
+func NewLayeredDictionary(base *Dictionary) *LayeredDictionary {
+	return &LayeredDictionary{base: base}
+}
+
+func (d *LayeredDictionary) Locale() *Locale {
+	return d.base.Locale()
+}
+
+// Base returns the underlying dictionary.
+func (d *LayeredDictionary) Base() *Dictionary {
+	return d.base
+}
+
+// AddLayer adds an override catalog with the given name on top of all other
+// layers. If a layer with the same name already exists, it will be replaced and
+// moved to the top. The catalog must belong to the locale of the base dictionary.
+func (d *LayeredDictionary) AddLayer(name string, cat *Catalog) error {
+	if cat == nil {
+		return fmt.Errorf("missing catalog for layer %s", name)
+	}
+	if !sameLocale(cat.LocaleID(), d.base.loc.ID()) {
+		return fmt.Errorf("catalog for locale %s does not match dictionary locale %s", cat.LocaleID(), d.base.loc.ID())
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	// The layers are copied on write, so readers can keep using a previous
+	// snapshot without holding the lock.
+	layers := make([]layer, 0, len(d.layers)+1)
+	for _, l := range d.layers {
+		if l.name != name {
+			layers = append(layers, l)
+		}
+	}
+	d.layers = append(layers, layer{name: name, cat: cat})
+	return nil
+}
+
+// RemoveLayer removes the layer with the given name. It reports whether the
+// layer existed.
+func (d *LayeredDictionary) RemoveLayer(name string) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	idx := slices.IndexFunc(d.layers, func(l layer) bool { return l.name == name })
+	if idx < 0 {
+		return false
+	}
+	d.layers = slices.Delete(slices.Clone(d.layers), idx, idx+1)
+	return true
+}
+
+// Layers returns the names of all layers ordered by priority, the highest
+// priority comes first.
+func (d *LayeredDictionary) Layers() []string {
+	layers := d.snapshot()
+	names := make([]string, len(layers))
+	for i, l := range layers {
+		names[len(layers)-1-i] = l.name
+	}
+	return names
+}
+
+func (d *LayeredDictionary) Translate(section string, messageKey string, ctx Context) string {
+	msg := d.message(section, messageKey)
+	if msg == nil {
+		return ""
+	}
+	return msg.Format(d.base.loc, ctx)
+}
+
+// Ref returns a reference to the message with the given section and message key.
+// The reference is resolved against the current layers, i.e. layers which are
+// added or removed afterwards do not affect the reference.
+func (d *LayeredDictionary) Ref(section string, messageKey string) MessageRef {
+	msg := d.message(section, messageKey)
+	if msg == nil {
+		return MessageRef{}
+	}
+	return MessageRef{loc: d.base.loc, msg: msg}
+}
+
+func (d *LayeredDictionary) message(section string, key string) *Message {
+	layers := d.snapshot()
+	for i := len(layers) - 1; i >= 0; i-- {
+		if msg := layers[i].cat.Message(section, key); msg != nil {
+			return msg
+		}
+	}
+	return d.base.cat.Message(section, key)
+}
+
+func (d *LayeredDictionary) snapshot() []layer {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return d.layers
+}
@@ -0,0 +1,113 @@
+package lxn
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestLayeredDictionary(t *testing.T) {
+	newCat := func(localeID string, texts map[string]string) *Catalog {
+		msgs := make([]lxn.Message, 0, len(texts))
+		for key, text := range texts {
+			msgs = append(msgs, lxn.Message{Key: key, Text: []string{text}})
+		}
+		cat, err := newCatalog(localeID, msgs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return cat
+	}
+
+	base, err := NewDictionary(newLocale(lxn.Locale{ID: "en"}), newCat("en", map[string]string{
+		"projects": "Projects",
+		"teams":    "Teams",
+		"users":    "Users",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dic := NewLayeredDictionary(base)
+	if err := dic.AddLayer("tenant", newCat("en", map[string]string{"projects": "Workspaces", "teams": "Squads"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dic.AddLayer("user", newCat("en", map[string]string{"teams": "Crews"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := dic.AddLayer("other", newCat("de", map[string]string{"users": "Benutzer"})); err == nil {
+		t.Errorf("expected error for mismatching locale")
+	}
+	if err := dic.AddLayer("other", nil); err == nil {
+		t.Errorf("expected error for missing catalog")
+	}
+
+	check := func(expected map[string]string) {
+		t.Helper()
+		for key, text := range expected {
+			if got := dic.Translate("", key, nil); got != text {
+				t.Errorf("unexpected translation for %s: %q", key, got)
+			}
+			if got := dic.Ref("", key).Translate(nil); got != text {
+				t.Errorf("unexpected reference translation for %s: %q", key, got)
+			}
+		}
+	}
+
+	check(map[string]string{"projects": "Workspaces", "teams": "Crews", "users": "Users", "unknown": ""})
+	if layers := dic.Layers(); !reflect.DeepEqual(layers, []string{"user", "tenant"}) {
+		t.Errorf("unexpected layers: %v", layers)
+	}
+
+	if !dic.RemoveLayer("user") {
+		t.Errorf("expected layer to be removed")
+	}
+	if dic.RemoveLayer("user") {
+		t.Errorf("expected layer to be removed already")
+	}
+	check(map[string]string{"projects": "Workspaces", "teams": "Squads", "users": "Users"})
+
+	if err := dic.AddLayer("tenant", newCat("en", map[string]string{"users": "Members"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	check(map[string]string{"projects": "Projects", "teams": "Teams", "users": "Members"})
+	if got := base.Translate("", "users", nil); got != "Users" {
+		t.Errorf("unexpected base translation: %q", got)
+	}
+}
+
+func TestLayeredDictionaryConcurrency(t *testing.T) {
+	cat, err := newCatalog("en", []lxn.Message{{Key: "key", Text: []string{"base"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	override, err := newCatalog("en", []lxn.Message{{Key: "key", Text: []string{"override"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dic := NewLayeredDictionary(&Dictionary{loc: newLocale(lxn.Locale{ID: "en"}), cat: cat})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if got := dic.Translate("", "key", nil); got != "base" && got != "override" {
+					t.Errorf("unexpected translation: %q", got)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = dic.AddLayer("override", override)
+				dic.RemoveLayer("override")
+			}
+		}()
+	}
+	wg.Wait()
+}
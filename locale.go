@@ -2,6 +2,7 @@ package lxn
 
 import (
 	"io"
+	"strconv"
 
 	"github.com/mprot/msgpack-go"
 
@@ -9,7 +10,8 @@ import (
 )
 
 type Locale struct {
-	loc lxn.Locale
+	loc          lxn.Locale
+	scalePercent bool
 }
 
 // ReadLocale reads the locale information from the given binary
@@ -29,3 +31,209 @@ func newLocale(loc lxn.Locale) *Locale {
 func (l *Locale) ID() string {
 	return l.loc.ID
 }
+
+// DecimalFormat returns the format for decimal numbers.
+func (l *Locale) DecimalFormat() NumberFormat {
+	return newNumberFormat(&l.loc.DecimalFormat)
+}
+
+// PercentFormat returns the format for percent values.
+func (l *Locale) PercentFormat() NumberFormat {
+	return newNumberFormat(&l.loc.PercentFormat)
+}
+
+// MoneyFormat returns the format for amounts of money. The affixes contain the
+// currency placeholder '¤'.
+func (l *Locale) MoneyFormat() NumberFormat {
+	return newNumberFormat(&l.loc.MoneyFormat)
+}
+
+// AccountingFormat returns the format for amounts of money in accounting, which
+// usually wraps negative amounts in parentheses. If the locale has no accounting
+// format, the money format is returned.
+func (l *Locale) AccountingFormat() NumberFormat {
+	if l.loc.AccountingFormat.Symbols.Zero == 0 {
+		return l.MoneyFormat()
+	}
+	return newNumberFormat(&l.loc.AccountingFormat)
+}
+
+// Symbols returns the symbols used for formatting decimal numbers.
+func (l *Locale) Symbols() Symbols {
+	return newSymbols(&l.loc.DecimalFormat.Symbols)
+}
+
+// ZeroDigit returns the zero digit of the locale's native numbering system,
+// e.g. '0' for Latin digits or '٠' for Arabic-Indic digits.
+func (l *Locale) ZeroDigit() rune {
+	return rune(l.loc.DecimalFormat.Symbols.Zero)
+}
+
+// CardinalRules returns the rules for the cardinal plural categories, i.e. the
+// categories used for counting things.
+func (l *Locale) CardinalRules() []PluralRule {
+	return pluralRules(l.plurals(false))
+}
+
+// OrdinalRules returns the rules for the ordinal plural categories, i.e. the
+// categories used for ranks like 1st or 2nd.
+func (l *Locale) OrdinalRules() []PluralRule {
+	return pluralRules(l.plurals(true))
+}
+
+// WithPercentScaling returns a copy of the locale which treats percent values as
+// ratios, i.e. 0.25 is formatted as 25%. Replacements with an explicit percent
+// scale are not affected.
+func (l *Locale) WithPercentScaling() *Locale {
+	c := *l
+	c.scalePercent = true
+	return &c
+}
+
+// percentScale resolves the default percent scale to the locale's setting.
+func (l *Locale) percentScale(scale lxn.PercentScale) lxn.PercentScale {
+	switch {
+	case scale != lxn.PercentScaleDefault:
+		return scale
+	case l.scalePercent:
+		return lxn.PercentScaleHundred
+	default:
+		return lxn.PercentScaleNone
+	}
+}
+
+// NumberFormat describes how numbers are formatted in a locale. The affixes may
+// contain placeholders for the locale's symbols: '-' for the minus sign, '+' for
+// the plus sign, '%' for the percent sign, '‰' for the per-mille sign, and '¤'
+// for the currency.
+type NumberFormat struct {
+	Symbols                  Symbols
+	PositivePrefix           string
+	PositiveSuffix           string
+	NegativePrefix           string
+	NegativeSuffix           string
+	MinIntegerDigits         int
+	MinFractionDigits        int
+	MaxFractionDigits        int
+	MinSignificantDigits     int // 0 if the number of significant digits is not restricted
+	MaxSignificantDigits     int // 0 if the number of significant digits is not restricted
+	PrimaryIntegerGrouping   int // 0 if integer digits are not grouped
+	SecondaryIntegerGrouping int
+	FractionGrouping         int // 0 if fraction digits are not grouped
+	MinGroupingDigits        int
+	RoundingMode             RoundingMode
+	SignDisplay              SignDisplay
+}
+
+func newNumberFormat(nf *lxn.NumberFormat) NumberFormat {
+	return NumberFormat{
+		Symbols:                  newSymbols(&nf.Symbols),
+		PositivePrefix:           nf.PositivePrefix,
+		PositiveSuffix:           nf.PositiveSuffix,
+		NegativePrefix:           nf.NegativePrefix,
+		NegativeSuffix:           nf.NegativeSuffix,
+		MinIntegerDigits:         nf.MinIntegerDigits,
+		MinFractionDigits:        nf.MinFractionDigits,
+		MaxFractionDigits:        nf.MaxFractionDigits,
+		MinSignificantDigits:     nf.MinSignificantDigits,
+		MaxSignificantDigits:     nf.MaxSignificantDigits,
+		PrimaryIntegerGrouping:   nf.PrimaryIntegerGrouping,
+		SecondaryIntegerGrouping: nf.SecondaryIntegerGrouping,
+		FractionGrouping:         nf.FractionGrouping,
+		MinGroupingDigits:        nf.MinGroupingDigits,
+		RoundingMode:             RoundingMode(nf.RoundingMode),
+		SignDisplay:              SignDisplay(nf.SignDisplay),
+	}
+}
+
+// RoundingMode is a CLDR rounding mode, which defines how numbers are rounded to
+// the number of digits of a number format.
+type RoundingMode int
+
+// Rounding modes as defined by CLDR.
+const (
+	RoundHalfEven = RoundingMode(lxn.RoundHalfEven)
+	RoundHalfUp   = RoundingMode(lxn.RoundHalfUp)
+	RoundHalfDown = RoundingMode(lxn.RoundHalfDown)
+	RoundCeiling  = RoundingMode(lxn.RoundCeiling)
+	RoundFloor    = RoundingMode(lxn.RoundFloor)
+	RoundDown     = RoundingMode(lxn.RoundDown)
+	RoundUp       = RoundingMode(lxn.RoundUp)
+)
+
+var roundingModeNames = [...]string{
+	lxn.RoundHalfEven: "half-even",
+	lxn.RoundHalfUp:   "half-up",
+	lxn.RoundHalfDown: "half-down",
+	lxn.RoundCeiling:  "ceiling",
+	lxn.RoundFloor:    "floor",
+	lxn.RoundDown:     "down",
+	lxn.RoundUp:       "up",
+}
+
+// String returns the CLDR name of the rounding mode, e.g. "half-even".
+func (m RoundingMode) String() string {
+	if 0 <= m && int(m) < len(roundingModeNames) {
+		return roundingModeNames[m]
+	}
+	return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// SignDisplay defines for which numbers a number format displays the sign.
+type SignDisplay int
+
+// Sign display modes: auto displays the sign for negative numbers only, always
+// for all numbers, never for no number, and except-zero for all numbers except
+// zero.
+const (
+	SignAuto       = SignDisplay(lxn.SignAuto)
+	SignAlways     = SignDisplay(lxn.SignAlways)
+	SignNever      = SignDisplay(lxn.SignNever)
+	SignExceptZero = SignDisplay(lxn.SignExceptZero)
+)
+
+var signDisplayNames = [...]string{
+	lxn.SignAuto:       "auto",
+	lxn.SignAlways:     "always",
+	lxn.SignNever:      "never",
+	lxn.SignExceptZero: "except-zero",
+}
+
+// String returns the name of the sign display mode, e.g. "except-zero".
+func (d SignDisplay) String() string {
+	if 0 <= d && int(d) < len(signDisplayNames) {
+		return signDisplayNames[d]
+	}
+	return "SignDisplay(" + strconv.Itoa(int(d)) + ")"
+}
+
+// Symbols holds the symbols which are used for formatting numbers.
+type Symbols struct {
+	Decimal                string
+	Group                  string
+	Percent                string
+	PerMille               string
+	Minus                  string
+	Plus                   string
+	Infinity               string
+	NaN                    string
+	Exponential            string
+	SuperscriptingExponent string
+	Zero                   rune
+}
+
+func newSymbols(symb *lxn.Symbols) Symbols {
+	return Symbols{
+		Decimal:                symb.Decimal,
+		Group:                  symb.Group,
+		Percent:                symb.Percent,
+		PerMille:               symb.PerMille,
+		Minus:                  symb.Minus,
+		Plus:                   symb.Plus,
+		Infinity:               symb.Inf,
+		NaN:                    symb.Nan,
+		Exponential:            symb.Exponential,
+		SuperscriptingExponent: symb.SuperscriptingExponent,
+		Zero:                   rune(symb.Zero),
+	}
+}
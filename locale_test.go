@@ -0,0 +1,146 @@
+package lxn
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestLocaleAccessors(t *testing.T) {
+	symb := lxn.Symbols{
+		Decimal:     ",",
+		Group:       ".",
+		Percent:     "%",
+		PerMille:    "‰",
+		Minus:       "-",
+		Plus:        "+",
+		Inf:         "∞",
+		Nan:         "NaN",
+		Zero:        '٠',
+		Exponential: "E",
+	}
+	loc := newLocale(lxn.Locale{
+		ID: "de",
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  symb,
+			NegativePrefix:           "-",
+			MinIntegerDigits:         1,
+			MaxFractionDigits:        3,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+			MinGroupingDigits:        2,
+		},
+		PercentFormat: lxn.NumberFormat{
+			Symbols:        symb,
+			PositiveSuffix: " %",
+			NegativePrefix: "-",
+			NegativeSuffix: " %",
+		},
+		MoneyFormat: lxn.NumberFormat{
+			Symbols:           symb,
+			PositiveSuffix:    " ¤",
+			MinFractionDigits: 2,
+			MaxFractionDigits: 2,
+			RoundingMode:      lxn.RoundHalfUp,
+		},
+		AccountingFormat: lxn.NumberFormat{
+			Symbols:           symb,
+			PositiveSuffix:    " ¤",
+			NegativePrefix:    "(",
+			NegativeSuffix:    " ¤)",
+			MinFractionDigits: 2,
+			MaxFractionDigits: 2,
+			SignDisplay:       lxn.SignExceptZero,
+		},
+	})
+
+	expectedSymbols := Symbols{
+		Decimal:     ",",
+		Group:       ".",
+		Percent:     "%",
+		PerMille:    "‰",
+		Minus:       "-",
+		Plus:        "+",
+		Infinity:    "∞",
+		NaN:         "NaN",
+		Exponential: "E",
+		Zero:        '٠',
+	}
+
+	if got := loc.Symbols(); got != expectedSymbols {
+		t.Errorf("unexpected symbols: %+v", got)
+	}
+	if got := loc.ZeroDigit(); got != '٠' {
+		t.Errorf("unexpected zero digit: %q", got)
+	}
+
+	tests := []struct {
+		nf       NumberFormat
+		expected NumberFormat
+	}{
+		{
+			nf: loc.DecimalFormat(),
+			expected: NumberFormat{
+				Symbols:                  expectedSymbols,
+				NegativePrefix:           "-",
+				MinIntegerDigits:         1,
+				MaxFractionDigits:        3,
+				PrimaryIntegerGrouping:   3,
+				SecondaryIntegerGrouping: 3,
+				MinGroupingDigits:        2,
+			},
+		},
+		{
+			nf: loc.PercentFormat(),
+			expected: NumberFormat{
+				Symbols:        expectedSymbols,
+				PositiveSuffix: " %",
+				NegativePrefix: "-",
+				NegativeSuffix: " %",
+			},
+		},
+		{
+			nf: loc.MoneyFormat(),
+			expected: NumberFormat{
+				Symbols:           expectedSymbols,
+				PositiveSuffix:    " ¤",
+				MinFractionDigits: 2,
+				MaxFractionDigits: 2,
+				RoundingMode:      RoundHalfUp,
+			},
+		},
+		{
+			nf: loc.AccountingFormat(),
+			expected: NumberFormat{
+				Symbols:           expectedSymbols,
+				PositiveSuffix:    " ¤",
+				NegativePrefix:    "(",
+				NegativeSuffix:    " ¤)",
+				MinFractionDigits: 2,
+				MaxFractionDigits: 2,
+				SignDisplay:       SignExceptZero,
+			},
+		},
+		{
+			nf:       newLocale(lxn.Locale{MoneyFormat: lxn.NumberFormat{Symbols: lxn.Symbols{Zero: '0'}, MaxFractionDigits: 2}}).AccountingFormat(),
+			expected: NumberFormat{Symbols: Symbols{Zero: '0'}, MaxFractionDigits: 2},
+		},
+	}
+
+	for i, test := range tests {
+		if !reflect.DeepEqual(test.nf, test.expected) {
+			t.Errorf("unexpected number format %d: %+v", i, test.nf)
+		}
+	}
+
+	if s := RoundHalfUp.String(); s != "half-up" {
+		t.Errorf("unexpected rounding mode name: %s", s)
+	}
+	if s := SignExceptZero.String(); s != "except-zero" {
+		t.Errorf("unexpected sign display name: %s", s)
+	}
+	if s := RoundingMode(42).String(); s != "RoundingMode(42)" {
+		t.Errorf("unexpected name for invalid rounding mode: %s", s)
+	}
+}
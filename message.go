@@ -9,11 +9,12 @@ import (
 const noCurrency = string(currencyPlaceholder)
 
 type Message struct {
-	msg lxn.Message
+	msg  lxn.Message
+	plan *plan
 }
 
 func newMessage(m lxn.Message) *Message {
-	return &Message{msg: m}
+	return &Message{msg: m, plan: compile(&m)}
 }
 
 func (m *Message) Section() string {
@@ -26,113 +27,164 @@ func (m *Message) Key() string {
 
 func (m *Message) Format(loc *Locale, ctx Context) string {
 	var w writer
-	formatMsg(&w, &m.msg, ctx, &loc.loc)
+	w.Grow(m.plan.size)
+	m.plan.format(&w, ctx, loc)
 	return w.String()
 }
 
-func formatMsg(w *writer, m *lxn.Message, ctx Context, loc *lxn.Locale) {
-	off := 0
-	for i, t := range m.Text {
-		for off < len(m.Replacements) && m.Replacements[off].TextPos <= i {
-			replace(w, &m.Replacements[off], ctx, loc)
-			off++
-		}
-		w.WriteString(t)
-	}
-	for _, r := range m.Replacements[off:] {
-		replace(w, &r, ctx, loc)
-	}
-}
-
-func replace(w *writer, r *lxn.Replacement, ctx Context, loc *lxn.Locale) {
-	v, has := ctx[r.Key]
+func (r *replacement) format(w *writer, ctx Context, l *Locale) {
+	loc := &l.loc
+	v, has := ctx[r.key]
 	if !has {
-		w.MissingVar(r.Key)
+		w.MissingVar(r.key)
+		return
+	}
+	if r.corrupted {
+		w.Corrupted(r.key)
 		return
 	}
 
-	switch r.Type {
+	switch r.typ {
 	case lxn.StringReplacement:
 		w.WriteString(v.String())
 
 	case lxn.NumberReplacement:
-		replaceNumber(w, v, r.Key, &loc.DecimalFormat, noCurrency)
+		replaceDecimal(w, v, r.key, r.number, r.skeleton, loc)
 
 	case lxn.PercentReplacement:
-		replaceNumber(w, v, r.Key, &loc.PercentFormat, noCurrency)
+		replacePercent(w, v, r.key, r.number, r.skeleton, l)
 
 	case lxn.MoneyReplacement:
-		details, ok := r.Details.Value.(lxn.MoneyDetails)
-		if !ok {
-			w.Corrupted(r.Key)
-		} else if curr, has := ctx[details.Currency]; has {
-			replaceNumber(w, v, r.Key, &loc.MoneyFormat, curr.String())
+		if currency, has := moneyCurrency(v, ctx, r.money); has {
+			replaceMoney(w, v, r.key, currency, r.money, r.skeleton, loc)
 		} else {
-			w.MissingVar(details.Currency)
+			w.MissingVar(r.money.Currency)
 		}
 
 	case lxn.PluralReplacement:
-		details, ok := r.Details.Value.(lxn.PluralDetails)
-		if !ok {
-			w.Corrupted(r.Key)
-		} else {
-			replacePlural(w, v, ctx, &details, loc)
-		}
+		replacePlural(w, v, ctx, r.plural, l)
 
 	case lxn.SelectReplacement:
-		details, ok := r.Details.Value.(lxn.SelectDetails)
-		if !ok {
-			w.Corrupted(r.Key)
-		} else {
-			replaceSelect(w, v, ctx, &details, loc)
-		}
+		replaceSelect(w, v, ctx, r.sel, l)
+
+	case lxn.TimeReplacement:
+		replaceTime(w, v, r, l)
+
+	default:
+		w.UnsupportedReplType(r.typ)
+	}
+}
+
+func replaceDecimal(w *writer, v Variable, key string, details *lxn.NumberDetails, sk skeleton, loc *lxn.Locale) {
+	num, isNum := v.(number)
+	if !isNum {
+		w.InvalidType(key)
+		return
+	}
 
+	nf := skeletonFormat(&loc.DecimalFormat, sk)
+	switch details.Style {
+	case lxn.CompactShortStyle:
+		formatCompact(w, num, nf, loc.CompactFormat.Short, loc.CardinalPlurals)
+	case lxn.CompactLongStyle:
+		formatCompact(w, num, nf, loc.CompactFormat.Long, loc.CardinalPlurals)
+	case lxn.ScientificStyle:
+		formatScientific(w, num, nf, 1, details.MantissaDigits, details.SuperscriptExponent)
+	case lxn.EngineeringStyle:
+		formatScientific(w, num, nf, 3, details.MantissaDigits, details.SuperscriptExponent)
 	default:
-		w.UnsupportedReplType(r.Type)
+		num.format(w, nf, noCurrency)
 	}
 }
 
-func replaceNumber(w *writer, v Variable, key string, nf *lxn.NumberFormat, currency string) {
+// replacePercent formats a percent value. Depending on the percent scale, the value
+// is scaled before formatting it.
+func replacePercent(w *writer, v Variable, key string, details *lxn.NumberDetails, sk skeleton, loc *Locale) {
+	num, isNum := v.(number)
+	if !isNum {
+		w.InvalidType(key)
+		return
+	}
+
+	num, nf := percentNumber(num, &loc.loc.PercentFormat, loc.percentScale(details.PercentScale))
+	num.format(w, skeletonFormat(nf, sk), noCurrency)
+}
+
+func replaceMoney(w *writer, v Variable, key string, currency string, details *lxn.MoneyDetails, sk skeleton, loc *lxn.Locale) {
+	if money, isMoney := v.(Money); isMoney {
+		v = money.Amount
+	}
 	if num, isNum := v.(number); isNum {
-		num.format(w, nf, currency)
+		nf, symbol, num := moneyFormat(num, loc, currency, details.Display, details.Style, sk)
+		num.format(w, &nf, symbol)
 	} else {
 		w.InvalidType(key)
 	}
 }
 
-func replacePlural(w *writer, v Variable, ctx Context, details *lxn.PluralDetails, loc *lxn.Locale) {
+func replaceTime(w *writer, v Variable, r *replacement, loc *Locale) {
+	t, isTime := v.(Time)
+	if !isTime {
+		w.InvalidType(r.key)
+		return
+	}
+
+	pattern, err := r.resolveTimePattern(loc)
+	if err != nil {
+		w.Corrupted(r.key)
+		return
+	}
+	t.format(w, pattern, &loc.loc.Calendar, rune(loc.loc.DecimalFormat.Symbols.Zero))
+}
+
+// resolveTimePattern returns the date/time pattern of a time replacement for the
+// locale. Matching a skeleton is expensive, so the pattern of the last locale is
+// cached. Messages are usually formatted with the locale of their catalog, hence a
+// single entry is sufficient.
+func (r *replacement) resolveTimePattern(loc *Locale) (string, error) {
+	if e := r.timePattern.Load(); e != nil && e.loc == loc {
+		return e.pattern, e.err
+	}
+	pattern, err := timePattern(r.time, &loc.loc.Calendar)
+	r.timePattern.Store(&timePatternEntry{loc: loc, pattern: pattern, err: err})
+	return pattern, err
+}
+
+func replacePlural(w *writer, v Variable, ctx Context, p *pluralPlan, loc *Locale) {
 	tag := lxn.Other
 	if num, isNum := v.(number); isNum {
 		if i, ok := intval(num); ok {
-			if msg, has := details.Custom[i]; has {
-				formatMsg(w, &msg, ctx, loc)
+			if msg, has := p.custom[i]; has {
+				msg.format(w, ctx, loc)
 				return
 			}
 		}
-		plurals := loc.CardinalPlurals
-		if details.Type == lxn.Ordinal {
-			plurals = loc.OrdinalPlurals
+		plurals := loc.plurals(p.typ == lxn.Ordinal)
+		if p.percent {
+			num, nf := percentNumber(num, &loc.loc.PercentFormat, loc.percentScale(p.scale))
+			tag = pluralTag(num, nf, plurals)
+		} else {
+			tag = pluralTag(num, &loc.loc.DecimalFormat, plurals)
 		}
-		tag = pluralTag(num, &loc.DecimalFormat, plurals)
 	}
 
-	msg, has := details.Variants[tag]
+	msg, has := p.variants[tag]
 	if !has {
-		if msg, has = details.Variants[lxn.Other]; !has {
+		if msg = p.other; msg == nil {
 			return
 		}
 	}
-	formatMsg(w, &msg, ctx, loc)
+	msg.format(w, ctx, loc)
 }
 
-func replaceSelect(w *writer, v Variable, ctx Context, details *lxn.SelectDetails, loc *lxn.Locale) {
-	msg, has := details.Cases[v.String()]
+func replaceSelect(w *writer, v Variable, ctx Context, p *selectPlan, loc *Locale) {
+	msg, has := p.cases[v.String()]
 	if !has {
-		if msg, has = details.Cases[details.Fallback]; !has {
+		if msg = p.fallback; msg == nil {
 			return
 		}
 	}
-	formatMsg(w, &msg, ctx, loc)
+	msg.format(w, ctx, loc)
 }
 
 func intval(num number) (int64, bool) {
@@ -141,6 +193,10 @@ func intval(num number) (int64, bool) {
 		return int64(num), true
 	case Uint:
 		return int64(num), uint64(num) <= math.MaxInt64
+	case Decimal:
+		if num.intLen >= len(num.coeff) && num.intLen <= 18 {
+			return num.unscaled(0).Int64(), true
+		}
 	}
 	return 0, false
 }
@@ -2,6 +2,7 @@ package lxn
 
 import (
 	"testing"
+	"time"
 
 	"github.com/liblxn/lxn-go/internal/lxn"
 )
@@ -68,6 +69,64 @@ func TestMessageFormat(t *testing.T) {
 			expected: "foo 7 bar",
 		},
 
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.NumberReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.NumberDetails{Style: lxn.CompactShortStyle},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				DecimalFormat: lxn.NumberFormat{
+					Symbols: lxn.Symbols{Zero: '0', Decimal: "."},
+				},
+				CompactFormat: lxn.CompactFormat{
+					Short: []lxn.CompactPattern{
+						{Magnitude: 3, Category: lxn.Other, Exponent: 3, Suffix: "K"},
+					},
+				},
+			},
+			ctx: Context{
+				"replkey": Int(1234),
+			},
+			expected: "foo 1.2K bar",
+		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.NumberReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.NumberDetails{Skeleton: ".00 group-off sign-always"},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				DecimalFormat: lxn.NumberFormat{
+					Symbols:                  lxn.Symbols{Zero: '0', Decimal: ".", Group: ",", Plus: "+", Minus: "-"},
+					NegativePrefix:           "-",
+					MaxFractionDigits:        3,
+					PrimaryIntegerGrouping:   3,
+					SecondaryIntegerGrouping: 3,
+				},
+			},
+			ctx: Context{
+				"replkey": Float(1234.5),
+			},
+			expected: "foo +1234.50 bar",
+		},
+
 		// percent replacement
 		{
 			msg: lxn.Message{
@@ -91,6 +150,31 @@ func TestMessageFormat(t *testing.T) {
 			},
 			expected: "foo 7% bar",
 		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.PercentReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.NumberDetails{Skeleton: "integer-width/+000"},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				PercentFormat: lxn.NumberFormat{
+					Symbols:        lxn.Symbols{Zero: '0', Percent: "%"},
+					PositiveSuffix: "%",
+				},
+			},
+			ctx: Context{
+				"replkey": Uint(7),
+			},
+			expected: "foo 007% bar",
+		},
 
 		// money replacement
 		{
@@ -109,7 +193,7 @@ func TestMessageFormat(t *testing.T) {
 			},
 			loc: lxn.Locale{
 				MoneyFormat: lxn.NumberFormat{
-					Symbols:        lxn.Symbols{Zero: '0'},
+					Symbols:        lxn.Symbols{Zero: '0', Decimal: "."},
 					PositiveSuffix: string(currencyPlaceholder),
 				},
 			},
@@ -117,7 +201,89 @@ func TestMessageFormat(t *testing.T) {
 				"replkey": Uint(7),
 				"currkey": String("EUR"),
 			},
-			expected: "foo 7EUR bar",
+			expected: "foo 7.00EUR bar",
+		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.MoneyReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.MoneyDetails{Currency: "currkey"},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				MoneyFormat: lxn.NumberFormat{
+					Symbols:           lxn.Symbols{Zero: '0', Decimal: "."},
+					PositivePrefix:    string(currencyPlaceholder),
+					MinFractionDigits: 2,
+					MaxFractionDigits: 2,
+				},
+				Currencies: map[string]lxn.Currency{
+					"JPY": {Symbol: "¥", FractionDigits: 0},
+				},
+			},
+			ctx: Context{
+				"replkey": Float(1234),
+				"currkey": String("JPY"),
+			},
+			expected: "foo ¥1234 bar",
+		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.MoneyReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.MoneyDetails{Currency: "currkey"},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				MoneyFormat: lxn.NumberFormat{
+					Symbols:        lxn.Symbols{Zero: '0', Decimal: "."},
+					PositiveSuffix: string(currencyPlaceholder),
+				},
+			},
+			ctx: Context{
+				"replkey": Money{Amount: Uint(7), Currency: "EUR"},
+			},
+			expected: "foo 7.00EUR bar",
+		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.MoneyReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.MoneyDetails{Currency: "currkey"},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				MoneyFormat: lxn.NumberFormat{
+					Symbols:        lxn.Symbols{Zero: '0', Decimal: "."},
+					PositiveSuffix: string(currencyPlaceholder),
+				},
+			},
+			ctx: Context{
+				"replkey": Money{Amount: Uint(7)},
+				"currkey": String("USD"),
+			},
+			expected: "foo 7.00USD bar",
 		},
 
 		// plural replacement
@@ -344,6 +510,62 @@ func TestMessageFormat(t *testing.T) {
 			expected: "foo select bar",
 		},
 
+		// time replacement
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.TimeReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.TimeDetails{DateStyle: lxn.DateTimeShort},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				DecimalFormat: lxn.NumberFormat{
+					Symbols: lxn.Symbols{Zero: '0'},
+				},
+				Calendar: lxn.Calendar{
+					DateFormats: lxn.DateTimePatterns{Short: "dd.MM.y"},
+				},
+			},
+			ctx: Context{
+				"replkey": Time(time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC)),
+			},
+			expected: "foo 09.03.2024 bar",
+		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.TimeReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.TimeDetails{Skeleton: "Hms"},
+						},
+					},
+				},
+			},
+			loc: lxn.Locale{
+				DecimalFormat: lxn.NumberFormat{
+					Symbols: lxn.Symbols{Zero: '0'},
+				},
+				Calendar: lxn.Calendar{
+					AvailableFormats: map[string]string{"Hm": "HH:mm", "ms": "mm:ss"},
+				},
+			},
+			ctx: Context{
+				"replkey": Time(time.Date(2024, time.March, 9, 14, 5, 7, 0, time.UTC)),
+			},
+			expected: "foo %!(CORRUPTED:replkey) bar", // missing fields are not appended
+		},
+
 		// replacement positioning
 		{
 			msg: lxn.Message{
@@ -476,6 +698,144 @@ func TestMessageFormat(t *testing.T) {
 	}
 }
 
+func TestMessageFormatTimePatternCache(t *testing.T) {
+	msg := newMessage(lxn.Message{
+		Text: []string{"", ""},
+		Replacements: []lxn.Replacement{
+			{
+				Key:     "replkey",
+				TextPos: 1,
+				Type:    lxn.TimeReplacement,
+				Details: lxn.ReplacementDetails{
+					Value: lxn.TimeDetails{Skeleton: "yMd"},
+				},
+			},
+		},
+	})
+	repl := msg.plan.steps[0].repl
+
+	newTimeLocale := func(pattern string) *Locale {
+		return newLocale(lxn.Locale{
+			DecimalFormat: lxn.NumberFormat{
+				Symbols: lxn.Symbols{Zero: '0'},
+			},
+			Calendar: lxn.Calendar{
+				AvailableFormats: map[string]string{"yMd": pattern},
+			},
+		})
+	}
+	en, de := newTimeLocale("M/d/y"), newTimeLocale("d.M.y")
+	ctx := Context{"replkey": Time(time.Date(2024, time.March, 9, 0, 0, 0, 0, time.UTC))}
+
+	if got := msg.Format(en, ctx); got != "3/9/2024" {
+		t.Errorf("unexpected message format: %q", got)
+	}
+	cached := repl.timePattern.Load()
+	if got := msg.Format(en, ctx); got != "3/9/2024" {
+		t.Errorf("unexpected message format: %q", got)
+	}
+	if repl.timePattern.Load() != cached {
+		t.Errorf("expected time pattern to be cached")
+	}
+
+	if got := msg.Format(de, ctx); got != "9.3.2024" {
+		t.Errorf("unexpected message format for another locale: %q", got)
+	}
+	if got := msg.Format(en, ctx); got != "3/9/2024" {
+		t.Errorf("unexpected message format after changing the locale: %q", got)
+	}
+}
+
+func TestMessageFormatWithPercentScaling(t *testing.T) {
+	loc := lxn.Locale{
+		DecimalFormat: lxn.NumberFormat{
+			Symbols: lxn.Symbols{Zero: '0', Decimal: "."},
+		},
+		PercentFormat: lxn.NumberFormat{
+			Symbols:           lxn.Symbols{Zero: '0', Decimal: ".", Percent: "%", PerMille: "‰"},
+			PositiveSuffix:    "%",
+			MaxFractionDigits: 1,
+		},
+		CardinalPlurals: []lxn.Plural{
+			{
+				Category: lxn.One,
+				Rules: []lxn.PluralRule{
+					{Operand: lxn.AbsoluteValue, Ranges: []lxn.Range{{LowerBound: 1, UpperBound: 1}}},
+				},
+			},
+		},
+	}
+
+	percent := func(scale lxn.PercentScale) lxn.Message {
+		return lxn.Message{
+			Replacements: []lxn.Replacement{
+				{
+					Key:  "replkey",
+					Type: lxn.PercentReplacement,
+					Details: lxn.ReplacementDetails{
+						Value: lxn.NumberDetails{PercentScale: scale},
+					},
+				},
+			},
+		}
+	}
+
+	plural := func(percent bool, scale lxn.PercentScale) lxn.Message {
+		return lxn.Message{
+			Replacements: []lxn.Replacement{
+				{
+					Key:  "replkey",
+					Type: lxn.PluralReplacement,
+					Details: lxn.ReplacementDetails{
+						Value: lxn.PluralDetails{
+							Variants: map[lxn.PluralCategory]lxn.Message{
+								lxn.One:   {Text: []string{"one"}},
+								lxn.Other: {Text: []string{"other"}},
+							},
+							PercentScale: scale,
+							Percent:      percent,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		msg      lxn.Message
+		scaling  bool
+		value    number
+		expected string
+	}{
+		{msg: percent(lxn.PercentScaleDefault), value: Float(0.25), expected: "0.2%"},
+		{msg: percent(lxn.PercentScaleDefault), scaling: true, value: Float(0.25), expected: "25%"},
+		{msg: percent(lxn.PercentScaleNone), scaling: true, value: Float(25), expected: "25%"},
+		{msg: percent(lxn.PercentScaleHundred), value: Float(0.07), expected: "7%"},
+		{msg: percent(lxn.PercentScaleHundred), value: Int(3), expected: "300%"},
+		{msg: percent(lxn.PercentScaleThousand), value: mustParseDecimal("0.0255"), expected: "25.5‰"},
+		{msg: plural(false, lxn.PercentScaleDefault), scaling: true, value: Float(0.01), expected: "other"},
+		{msg: plural(false, lxn.PercentScaleHundred), value: Float(0.01), expected: "other"},
+		{msg: plural(false, lxn.PercentScaleDefault), value: Int(1), expected: "one"},
+		{msg: plural(true, lxn.PercentScaleDefault), scaling: true, value: Float(0.01), expected: "one"},
+		{msg: plural(true, lxn.PercentScaleDefault), value: Float(0.01), expected: "other"},
+		{msg: plural(true, lxn.PercentScaleNone), scaling: true, value: Int(1), expected: "one"},
+		{msg: plural(true, lxn.PercentScaleHundred), value: Float(0.01), expected: "one"},
+		{msg: plural(true, lxn.PercentScaleThousand), value: Float(0.001), expected: "one"},
+	}
+
+	for _, test := range tests {
+		l := newLocale(loc)
+		if test.scaling {
+			l = l.WithPercentScaling()
+		}
+
+		got := newMessage(test.msg).Format(l, Context{"replkey": test.value})
+		if got != test.expected {
+			t.Errorf("unexpected message format for %q: %q", test.expected, got)
+		}
+	}
+}
+
 func TestMessageFormatWithIncompleteInput(t *testing.T) {
 	tests := []struct {
 		msg      lxn.Message
@@ -519,6 +879,27 @@ func TestMessageFormatWithIncompleteInput(t *testing.T) {
 			expected: "foo %!(MISSING:currkey) bar",
 		},
 
+		// invalid money amount
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.MoneyReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.MoneyDetails{Currency: "currkey"},
+						},
+					},
+				},
+			},
+			ctx: Context{
+				"replkey": Money{Amount: String("7"), Currency: "EUR"},
+			},
+			expected: "foo %!(INVALID:replkey) bar",
+		},
+
 		// unsupported replacement type
 		{
 			msg: lxn.Message{
@@ -537,6 +918,27 @@ func TestMessageFormatWithIncompleteInput(t *testing.T) {
 			expected: "foo %!(UNSUPPORTED:ReplType-99) bar",
 		},
 
+		// invalid number skeleton
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.NumberReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.NumberDetails{Skeleton: "unknown-stem"},
+						},
+					},
+				},
+			},
+			ctx: Context{
+				"replkey": Int(7),
+			},
+			expected: "foo %!(CORRUPTED:replkey) bar",
+		},
+
 		// invalid number type
 		{
 			msg: lxn.Message{
@@ -555,6 +957,27 @@ func TestMessageFormatWithIncompleteInput(t *testing.T) {
 			expected: "foo %!(INVALID:replkey) bar",
 		},
 
+		// invalid time type
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.TimeReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.TimeDetails{},
+						},
+					},
+				},
+			},
+			ctx: Context{
+				"replkey": Int(7),
+			},
+			expected: "foo %!(INVALID:replkey) bar",
+		},
+
 		// corrupted details
 		{
 			msg: lxn.Message{
@@ -604,6 +1027,22 @@ func TestMessageFormatWithIncompleteInput(t *testing.T) {
 			},
 			expected: "foo %!(CORRUPTED:replkey) bar",
 		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar"},
+				Replacements: []lxn.Replacement{
+					{
+						Key:     "replkey",
+						TextPos: 1,
+						Type:    lxn.TimeReplacement,
+					},
+				},
+			},
+			ctx: Context{
+				"replkey": String("foo"),
+			},
+			expected: "foo %!(CORRUPTED:replkey) bar",
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,38 @@
+package lxn
+
+import (
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// Money is an amount of money which can be passed to money replacements. The
+// amount has to be a numeric variable (e.g. Int, Uint, or Float) and the currency
+// is an ISO 4217 currency code. If the currency is empty, the currency will be
+// taken from the context variable named in the replacement.
+type Money struct {
+	Amount   Variable
+	Currency string
+}
+
+// String implements the Variable interface.
+func (m Money) String() string {
+	if m.Amount == nil {
+		return m.Currency
+	}
+	if m.Currency == "" {
+		return m.Amount.String()
+	}
+	return m.Amount.String() + " " + m.Currency
+}
+
+// moneyCurrency returns the currency code for a money replacement. A Money variable
+// carries its own currency, all other variables need a separate currency variable
+// in the context.
+func moneyCurrency(v Variable, ctx Context, details *lxn.MoneyDetails) (string, bool) {
+	if money, isMoney := v.(Money); isMoney && money.Currency != "" {
+		return money.Currency, true
+	}
+	if curr, has := ctx[details.Currency]; has {
+		return curr.String(), true
+	}
+	return "", false
+}
@@ -3,6 +3,7 @@ package lxn
 import (
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/liblxn/lxn-go/internal/lxn"
 )
@@ -19,9 +20,50 @@ var (
 	_ number = Float(0)
 )
 
+// signAffixes returns the prefix and suffix for a number with respect to the sign
+// display of the number format. The zero flag reports whether all displayed digits
+// of the number are zero.
+func signAffixes(nf *lxn.NumberFormat, negative bool, zero bool) (string, string) {
+	switch {
+	case nf.SignDisplay == lxn.SignNever || (nf.SignDisplay == lxn.SignExceptZero && zero):
+		return nf.PositivePrefix, nf.PositiveSuffix
+	case negative:
+		return nf.NegativePrefix, nf.NegativeSuffix
+	case nf.SignDisplay == lxn.SignAlways || nf.SignDisplay == lxn.SignExceptZero:
+		// The affixes for an explicit plus sign are derived from the negative ones
+		// by replacing the minus sign. If there is no minus sign (e.g. for the
+		// accounting format), the plus sign is prepended.
+		minus, plus := string(minusPlaceholder), string(plusPlaceholder)
+		if !strings.Contains(nf.NegativePrefix, minus) && !strings.Contains(nf.NegativeSuffix, minus) {
+			return plus + nf.PositivePrefix, nf.PositiveSuffix
+		}
+		return strings.ReplaceAll(nf.NegativePrefix, minus, plus), strings.ReplaceAll(nf.NegativeSuffix, minus, plus)
+	default:
+		return nf.PositivePrefix, nf.PositiveSuffix
+	}
+}
+
+func isZero(intDigits []rune, fracDigits []rune, zero rune) bool {
+	for _, d := range intDigits {
+		if d != zero {
+			return false
+		}
+	}
+	for _, d := range fracDigits {
+		if d != zero {
+			return false
+		}
+	}
+	return true
+}
+
 const (
 	maxIntDigits   = 32 + 32 // integer + fraction digits
 	maxFloatDigits = 256
+
+	// maxPrecision is the maximum number of integer, fraction, or significant
+	// digits which can be requested for formatting.
+	maxPrecision = 999
 )
 
 // Int is a signed integer variable which can be passed to message replacements.
@@ -34,9 +76,13 @@ func (i Int) String() string {
 
 // returns (integer digits, fraction digits)
 func (i Int) digits(buf []rune, nf *lxn.NumberFormat, zero rune) ([]rune, []rune) {
-	if i < 0 {
+	neg := i < 0
+	if neg {
 		i = -i
 	}
+	if hasSignificantDigits(nf) {
+		return Uint(i).significantDigits(buf, nf, zero, neg)
+	}
 	return Uint(i).digits(buf, nf, zero)
 }
 
@@ -58,6 +104,16 @@ func (ui Uint) String() string {
 
 // returns (integer digits, fraction digits)
 func (ui Uint) digits(buf []rune, nf *lxn.NumberFormat, zero rune) ([]rune, []rune) {
+	if hasSignificantDigits(nf) {
+		return ui.significantDigits(buf, nf, zero, false)
+	}
+
+	// The buffer has to hold the integer digits (at most 20 for an uint64) and
+	// the padding. If it is too small, a new one will be allocated.
+	if n := max(nf.MinIntegerDigits, 20) + nf.MinFractionDigits; n > len(buf) {
+		buf = make([]rune, n)
+	}
+
 	// fractional digits
 	fracidx := len(buf)
 	for i := 0; i < nf.MinFractionDigits; i++ {
@@ -84,18 +140,37 @@ func (ui Uint) digits(buf []rune, nf *lxn.NumberFormat, zero rune) ([]rune, []ru
 	return buf[intidx:fracidx], buf[fracidx:]
 }
 
+// significantDigits returns the digits of the integer rounded to the significant
+// digits of the number format.
+func (ui Uint) significantDigits(buf []rune, nf *lxn.NumberFormat, zero rune, neg bool) ([]rune, []rune) {
+	var fmtbuf [20]byte
+	coeff := strconv.AppendUint(fmtbuf[:0], uint64(ui), 10)
+	if ui == 0 {
+		coeff = coeff[:0]
+	}
+	coeff, intLen, minFrac := roundNumber(coeff, len(coeff), neg, nf)
+	return coeffDigits(buf, coeff, intLen, minFrac, nf.MinIntegerDigits, zero)
+}
+
+func hasSignificantDigits(nf *lxn.NumberFormat) bool {
+	return nf.MinSignificantDigits > 0 || nf.MaxSignificantDigits > 0
+}
+
 func (ui Uint) format(w *writer, nf *lxn.NumberFormat, currency string) {
 	ui.fmt(w, nf, currency, false)
 }
 
 func (ui Uint) fmt(w *writer, nf *lxn.NumberFormat, currency string, negative bool) {
-	prefix, suffix := nf.PositivePrefix, nf.PositiveSuffix
-	if negative {
-		prefix, suffix = nf.NegativePrefix, nf.NegativeSuffix
+	var (
+		buf                   [maxIntDigits]rune
+		intDigits, fracDigits []rune
+	)
+	if hasSignificantDigits(nf) {
+		intDigits, fracDigits = ui.significantDigits(buf[:], nf, rune(nf.Symbols.Zero), negative)
+	} else {
+		intDigits, fracDigits = ui.digits(buf[:], nf, rune(nf.Symbols.Zero))
 	}
-
-	var buf [maxIntDigits]rune
-	intDigits, fracDigits := ui.digits(buf[:], nf, rune(nf.Symbols.Zero))
+	prefix, suffix := signAffixes(nf, negative, isZero(intDigits, fracDigits, rune(nf.Symbols.Zero)))
 
 	w.WriteAffix(prefix, &nf.Symbols, currency)
 	w.WriteInt(intDigits, nf)
@@ -113,46 +188,39 @@ func (f Float) String() string {
 
 // returns (integer digits, fraction digits)
 func (f Float) digits(buf []rune, nf *lxn.NumberFormat, zero rune) ([]rune, []rune) {
-	if f < 0 {
+	neg := f < 0
+	if neg {
 		f = -f
 	}
 
+	// The shortest decimal representation which uniquely identifies the float
+	// is rounded, i.e. 0.15 is treated as 0.15 and not as its binary
+	// approximation 0.1499999999999999944488848768742172978818416595458984375.
 	var fmtbuf [maxFloatDigits]byte
-	fmt := strconv.AppendFloat(fmtbuf[:0], float64(f), 'f', nf.MaxFractionDigits, 64)
+	fmt := strconv.AppendFloat(fmtbuf[:0], float64(f), 'e', -1, 64) // d[.ddd]e±dd
 
-	// trim trailing zeros
-	fmtidx := len(fmt)
-	for diff := nf.MaxFractionDigits - nf.MinFractionDigits; diff > 0 && fmt[fmtidx-1] == '0'; diff-- {
-		fmtidx--
+	epos := len(fmt) - 1
+	for fmt[epos] != 'e' {
+		epos--
 	}
-
-	// fractional digits
-	fracidx := len(buf)
-	if nf.MaxFractionDigits > 0 {
-		for fmtidx > 0 {
-			fmtidx--
-			d := fmt[fmtidx]
-			if d == '.' {
-				break
-			}
-			fracidx--
-			buf[fracidx] = zero + rune(d-'0')
-		}
+	exp := 0
+	for _, d := range fmt[epos+2:] {
+		exp = 10*exp + int(d-'0')
+	}
+	if fmt[epos+1] == '-' {
+		exp = -exp
 	}
 
-	// integer digits
-	intidx := fracidx
-	for fmtidx > 0 {
-		fmtidx--
-		intidx--
-		buf[intidx] = zero + rune(fmt[fmtidx]-'0')
+	coeff := fmt[:epos]
+	if len(coeff) > 1 {
+		coeff = append(coeff[:1], coeff[2:]...) // remove decimal point
 	}
-	for i := fracidx - intidx; i < nf.MinIntegerDigits; i++ {
-		intidx--
-		buf[intidx] = zero
+	if coeff[0] == '0' {
+		coeff = coeff[:0]
 	}
 
-	return buf[intidx:fracidx], buf[fracidx:]
+	coeff, intLen, minFrac := roundNumber(coeff, exp+1, neg, nf)
+	return coeffDigits(buf, coeff, intLen, minFrac, nf.MinIntegerDigits, zero)
 }
 
 func (f Float) format(w *writer, nf *lxn.NumberFormat, currency string) {
@@ -162,10 +230,7 @@ func (f Float) format(w *writer, nf *lxn.NumberFormat, currency string) {
 		return
 
 	case math.IsInf(float64(f), 0):
-		prefix, suffix := nf.PositivePrefix, nf.PositiveSuffix
-		if math.IsInf(float64(f), -1) {
-			prefix, suffix = nf.NegativePrefix, nf.NegativeSuffix
-		}
+		prefix, suffix := signAffixes(nf, math.IsInf(float64(f), -1), false)
 
 		w.WriteAffix(prefix, &nf.Symbols, currency)
 		w.WriteString(nf.Symbols.Inf)
@@ -175,11 +240,7 @@ func (f Float) format(w *writer, nf *lxn.NumberFormat, currency string) {
 
 	var buf [maxFloatDigits]rune
 	intDigits, fracDigits := f.digits(buf[:], nf, rune(nf.Symbols.Zero))
-
-	prefix, suffix := nf.PositivePrefix, nf.PositiveSuffix
-	if f < 0 {
-		prefix, suffix = nf.NegativePrefix, nf.NegativeSuffix
-	}
+	prefix, suffix := signAffixes(nf, f < 0, isZero(intDigits, fracDigits, rune(nf.Symbols.Zero)))
 
 	w.WriteAffix(prefix, &nf.Symbols, currency)
 	w.WriteInt(intDigits, nf)
@@ -2,11 +2,55 @@ package lxn
 
 import (
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/liblxn/lxn-go/internal/lxn"
 )
 
+func TestSignAffixes(t *testing.T) {
+	nf := lxn.NumberFormat{
+		PositiveSuffix: "%",
+		NegativePrefix: "-",
+		NegativeSuffix: "%",
+	}
+	accounting := lxn.NumberFormat{
+		PositivePrefix: "¤",
+		NegativePrefix: "(¤",
+		NegativeSuffix: ")",
+	}
+
+	tests := []struct {
+		nf       lxn.NumberFormat
+		display  lxn.SignDisplay
+		negative bool
+		zero     bool
+		expected string
+	}{
+		{nf: nf, display: lxn.SignAuto, negative: false, expected: "#%"},
+		{nf: nf, display: lxn.SignAuto, negative: true, expected: "-#%"},
+		{nf: nf, display: lxn.SignAuto, negative: true, zero: true, expected: "-#%"},
+		{nf: nf, display: lxn.SignAlways, negative: false, expected: "+#%"},
+		{nf: nf, display: lxn.SignAlways, negative: false, zero: true, expected: "+#%"},
+		{nf: nf, display: lxn.SignAlways, negative: true, expected: "-#%"},
+		{nf: nf, display: lxn.SignNever, negative: true, expected: "#%"},
+		{nf: nf, display: lxn.SignExceptZero, negative: false, expected: "+#%"},
+		{nf: nf, display: lxn.SignExceptZero, negative: true, expected: "-#%"},
+		{nf: nf, display: lxn.SignExceptZero, negative: false, zero: true, expected: "#%"},
+		{nf: nf, display: lxn.SignExceptZero, negative: true, zero: true, expected: "#%"},
+		{nf: accounting, display: lxn.SignAuto, negative: true, expected: "(¤#)"},
+		{nf: accounting, display: lxn.SignAlways, negative: false, expected: "+¤#"},
+	}
+
+	for _, test := range tests {
+		test.nf.SignDisplay = test.display
+		prefix, suffix := signAffixes(&test.nf, test.negative, test.zero)
+		if s := prefix + "#" + suffix; s != test.expected {
+			t.Errorf("unexpected affixes for %q: %s", test.expected, s)
+		}
+	}
+}
+
 func TestIntDigits(t *testing.T) {
 	tests := []struct {
 		val          Int
@@ -109,6 +153,34 @@ func TestIntFormat(t *testing.T) {
 			},
 			expected: "np123:00ns",
 		},
+		{
+			val: -1234567,
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero:  '0',
+					Group: ",",
+					Minus: "-",
+				},
+				NegativePrefix:           "-",
+				MaxSignificantDigits:     3,
+				PrimaryIntegerGrouping:   3,
+				SecondaryIntegerGrouping: 3,
+			},
+			expected: "-1,230,000",
+		},
+		{
+			val: -1234567,
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero:  '0',
+					Minus: "-",
+				},
+				NegativePrefix:       "-",
+				MaxSignificantDigits: 2,
+				RoundingMode:         lxn.RoundCeiling,
+			},
+			expected: "-1200000",
+		},
 	}
 
 	for _, test := range tests {
@@ -209,6 +281,42 @@ func TestUintFormat(t *testing.T) {
 			},
 			expected: "p0123:0s",
 		},
+		{
+			val: 1230,
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero:    '0',
+					Decimal: ".",
+					Group:   ",",
+				},
+				MinSignificantDigits:     3,
+				MaxSignificantDigits:     3,
+				PrimaryIntegerGrouping:   3,
+				SecondaryIntegerGrouping: 3,
+			},
+			expected: "1,230",
+		},
+		{
+			val: 7,
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero:    '0',
+					Decimal: ".",
+				},
+				MinSignificantDigits: 3,
+			},
+			expected: "7.00",
+		},
+		{
+			val: 18446744073709551615,
+			nf: lxn.NumberFormat{
+				Symbols: lxn.Symbols{
+					Zero: '0',
+				},
+				MaxSignificantDigits: 2,
+			},
+			expected: "18000000000000000000",
+		},
 	}
 
 	for _, test := range tests {
@@ -282,6 +390,99 @@ func TestFloatDigits(t *testing.T) {
 			expectedInt:  "123",
 			expectedFrac: "120",
 		},
+		{
+			val: 0.0001,
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 3,
+			},
+			expectedInt:  "0",
+			expectedFrac: "",
+		},
+		{
+			val: 0.15,
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 1,
+			},
+			expectedInt:  "0",
+			expectedFrac: "2",
+		},
+		{
+			val: 2.5,
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 0,
+				RoundingMode:      lxn.RoundHalfUp,
+			},
+			expectedInt:  "3",
+			expectedFrac: "",
+		},
+		{
+			val: -1.21,
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 1,
+				RoundingMode:      lxn.RoundCeiling,
+			},
+			expectedInt:  "1",
+			expectedFrac: "2",
+		},
+		{
+			val: -1.21,
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 1,
+				RoundingMode:      lxn.RoundFloor,
+			},
+			expectedInt:  "1",
+			expectedFrac: "3",
+		},
+		{
+			val: 9.99,
+			nf: lxn.NumberFormat{
+				MaxFractionDigits: 1,
+				RoundingMode:      lxn.RoundDown,
+			},
+			expectedInt:  "9",
+			expectedFrac: "9",
+		},
+		{
+			val: 0.001234,
+			nf: lxn.NumberFormat{
+				MaxFractionDigits:    2,
+				MinSignificantDigits: 3,
+				MaxSignificantDigits: 3,
+			},
+			expectedInt:  "0",
+			expectedFrac: "00123",
+		},
+		{
+			val: 99.96,
+			nf: lxn.NumberFormat{
+				MinSignificantDigits: 3,
+				MaxSignificantDigits: 3,
+			},
+			expectedInt:  "100",
+			expectedFrac: "",
+		},
+		{
+			val: 1.5,
+			nf: lxn.NumberFormat{
+				MinSignificantDigits: 4,
+			},
+			expectedInt:  "1",
+			expectedFrac: "500",
+		},
+		{
+			val: 0,
+			nf: lxn.NumberFormat{
+				MinSignificantDigits: 2,
+				MaxSignificantDigits: 3,
+			},
+			expectedInt:  "0",
+			expectedFrac: "0",
+		},
+		{
+			val:          1e300,
+			expectedInt:  "1" + strings.Repeat("0", 300),
+			expectedFrac: "",
+		},
 	}
 
 	var buf [maxFloatDigits]rune
@@ -0,0 +1,127 @@
+package lxn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// LocaleOption overrides a number formatting preference of a locale.
+type LocaleOption func(loc *Locale) error
+
+// DecimalSeparator overrides the decimal separator of all number formats.
+func DecimalSeparator(sep string) LocaleOption {
+	return func(loc *Locale) error {
+		for _, nf := range loc.numberFormats() {
+			nf.Symbols.Decimal = sep
+		}
+		return nil
+	}
+}
+
+// GroupSeparator overrides the group separator of all number formats.
+func GroupSeparator(sep string) LocaleOption {
+	return func(loc *Locale) error {
+		for _, nf := range loc.numberFormats() {
+			nf.Symbols.Group = sep
+		}
+		return nil
+	}
+}
+
+// Grouping overrides the integer grouping of all number formats. The primary
+// group is the one next to the decimal separator, all other groups are secondary
+// ones, e.g. 12,34,567 has a primary grouping of three and a secondary grouping
+// of two. A primary grouping of zero disables the grouping.
+func Grouping(primary int, secondary int) LocaleOption {
+	return func(loc *Locale) error {
+		switch {
+		case primary < 0 || secondary < 0:
+			return fmt.Errorf("invalid grouping %d/%d", primary, secondary)
+		case primary == 0:
+			secondary = 0
+		case secondary == 0:
+			secondary = primary
+		}
+		for _, nf := range loc.numberFormats() {
+			nf.PrimaryIntegerGrouping = primary
+			nf.SecondaryIntegerGrouping = secondary
+		}
+		return nil
+	}
+}
+
+// FractionDigits overrides the minimum and maximum number of fraction digits of
+// the decimal and percent format. Amounts of money keep the fraction digits of
+// their currency. At most 999 fraction digits are supported.
+func FractionDigits(minDigits int, maxDigits int) LocaleOption {
+	return func(loc *Locale) error {
+		if minDigits < 0 || maxDigits < minDigits || maxDigits > maxPrecision {
+			return fmt.Errorf("invalid fraction digits %d..%d", minDigits, maxDigits)
+		}
+		for _, nf := range []*lxn.NumberFormat{&loc.loc.DecimalFormat, &loc.loc.PercentFormat} {
+			nf.MinFractionDigits = minDigits
+			nf.MaxFractionDigits = maxDigits
+			nf.MinSignificantDigits, nf.MaxSignificantDigits = 0, 0
+		}
+		return nil
+	}
+}
+
+// CurrencyPattern overrides the affixes of the money format with the ones of a
+// CLDR number pattern, e.g. "#,##0.00 ¤" or "¤#,##0.00;(¤#,##0.00)". Only the
+// affixes of the pattern are used, the digits are still formatted according to
+// the money format and the currency. Without a negative subpattern, the negative
+// affixes are the positive ones with a leading minus sign.
+func CurrencyPattern(pattern string) LocaleOption {
+	return func(loc *Locale) error {
+		positive, negative, hasNegative := strings.Cut(pattern, ";")
+		posPrefix, posSuffix, ok := patternAffixes(positive)
+		if !ok {
+			return fmt.Errorf("invalid currency pattern %q", pattern)
+		}
+		negPrefix, negSuffix := string(minusPlaceholder)+posPrefix, posSuffix
+		if hasNegative {
+			if negPrefix, negSuffix, ok = patternAffixes(negative); !ok {
+				return fmt.Errorf("invalid currency pattern %q", pattern)
+			}
+		}
+
+		nf := &loc.loc.MoneyFormat
+		nf.PositivePrefix, nf.PositiveSuffix = posPrefix, posSuffix
+		nf.NegativePrefix, nf.NegativeSuffix = negPrefix, negSuffix
+		return nil
+	}
+}
+
+// patternAffixes returns the prefix and the suffix of a number pattern, i.e. the
+// parts before and after the digits.
+func patternAffixes(pattern string) (prefix string, suffix string, ok bool) {
+	const numberChars = "#0123456789@.,"
+	start := strings.IndexAny(pattern, numberChars)
+	if start < 0 {
+		return "", "", false
+	}
+	end := strings.LastIndexAny(pattern, numberChars) + 1
+	return pattern[:start], pattern[end:], true
+}
+
+// With returns a copy of the locale with the given options applied. The locale
+// itself is not modified. The options must not result in equal decimal and group
+// separators. The copy has the same id as the locale, so it can be
+// used with all catalogs and dictionaries of the locale (see Dictionary.WithLocale).
+func (l *Locale) With(opts ...LocaleOption) (*Locale, error) {
+	c := *l
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return nil, err
+		}
+	}
+	for _, nf := range c.numberFormats() {
+		if nf.Symbols.Zero != 0 && nf.Symbols.Decimal == nf.Symbols.Group {
+			return nil, fmt.Errorf("decimal and group separator are both %q", nf.Symbols.Decimal)
+		}
+	}
+	return &c, nil
+}
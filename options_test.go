@@ -0,0 +1,181 @@
+package lxn
+
+import (
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestLocaleWith(t *testing.T) {
+	symb := lxn.Symbols{Zero: '0', Decimal: ".", Group: ",", Minus: "-", Percent: "%"}
+	base := newLocale(lxn.Locale{
+		ID: "en-US",
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  symb,
+			NegativePrefix:           "-",
+			MaxFractionDigits:        3,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		PercentFormat: lxn.NumberFormat{
+			Symbols:           symb,
+			PositiveSuffix:    "%",
+			NegativePrefix:    "-",
+			NegativeSuffix:    "%",
+			MaxFractionDigits: 1,
+		},
+		MoneyFormat: lxn.NumberFormat{
+			Symbols:                  symb,
+			PositivePrefix:           "¤",
+			NegativePrefix:           "-¤",
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		Currencies: map[string]lxn.Currency{
+			"USD": {Symbol: "$", FractionDigits: 2},
+		},
+	})
+
+	tests := []struct {
+		opts     []LocaleOption
+		number   string
+		percent  string
+		money    string
+		negative string
+	}{
+		{
+			number:   "1,234,567.891",
+			percent:  "12.5%",
+			money:    "$1,234,567.89",
+			negative: "-$1.00",
+		},
+		{
+			opts:     []LocaleOption{DecimalSeparator(","), GroupSeparator("'")},
+			number:   "1'234'567,891",
+			percent:  "12,5%",
+			money:    "$1'234'567,89",
+			negative: "-$1,00",
+		},
+		{
+			opts:     []LocaleOption{GroupSeparator("."), DecimalSeparator(",")},
+			number:   "1.234.567,891",
+			percent:  "12,5%",
+			money:    "$1.234.567,89",
+			negative: "-$1,00",
+		},
+		{
+			opts:     []LocaleOption{Grouping(3, 2), FractionDigits(2, 2)},
+			number:   "12,34,567.89",
+			percent:  "12.50%",
+			money:    "$12,34,567.89",
+			negative: "-$1.00",
+		},
+		{
+			opts:     []LocaleOption{Grouping(0, 0), FractionDigits(0, 1)},
+			number:   "1234567.9",
+			percent:  "12.5%",
+			money:    "$1234567.89",
+			negative: "-$1.00",
+		},
+		{
+			opts:     []LocaleOption{CurrencyPattern("#,##0.00 ¤")},
+			number:   "1,234,567.891",
+			percent:  "12.5%",
+			money:    "1,234,567.89 $",
+			negative: "-1.00 $",
+		},
+		{
+			opts:     []LocaleOption{CurrencyPattern("¤ #,##0.00;(¤ #,##0.00)")},
+			number:   "1,234,567.891",
+			percent:  "12.5%",
+			money:    "$ 1,234,567.89",
+			negative: "($ 1.00)",
+		},
+	}
+
+	for i, test := range tests {
+		loc, err := base.With(test.opts...)
+		if err != nil {
+			t.Errorf("unexpected error for options %d: %v", i, err)
+			continue
+		}
+
+		switch {
+		case loc.ID() != base.ID():
+			t.Errorf("unexpected id for options %d: %s", i, loc.ID())
+		case loc.FormatNumber(Float(1234567.8912)) != test.number:
+			t.Errorf("unexpected number for options %d: %s", i, loc.FormatNumber(Float(1234567.8912)))
+		case loc.FormatPercent(Float(12.5)) != test.percent:
+			t.Errorf("unexpected percent for options %d: %s", i, loc.FormatPercent(Float(12.5)))
+		case loc.FormatMoney(Float(1234567.891), "USD") != test.money:
+			t.Errorf("unexpected money for options %d: %s", i, loc.FormatMoney(Float(1234567.891), "USD"))
+		case loc.FormatMoney(Int(-1), "USD") != test.negative:
+			t.Errorf("unexpected negative money for options %d: %s", i, loc.FormatMoney(Int(-1), "USD"))
+		}
+	}
+
+	for _, digits := range []int{70, maxPrecision} {
+		if loc, err := base.With(FractionDigits(digits, digits)); err != nil {
+			t.Errorf("unexpected error for %d fraction digits: %v", digits, err)
+		} else if got := loc.FormatNumber(Int(5)); len(got) != digits+2 {
+			t.Errorf("unexpected format for %d fraction digits: %d characters", digits, len(got))
+		}
+	}
+
+	if got := base.FormatNumber(Float(1234567.8912)); got != "1,234,567.891" {
+		t.Errorf("unexpected modification of the base locale: %s", got)
+	}
+
+	invalid := []LocaleOption{Grouping(-1, 0), FractionDigits(3, 2), FractionDigits(0, maxPrecision+1), CurrencyPattern("¤"), DecimalSeparator(","), GroupSeparator("."), CurrencyPattern("¤#;(¤)")}
+	for i, opt := range invalid {
+		if _, err := base.With(opt); err == nil {
+			t.Errorf("expected error for invalid option %d", i)
+		}
+	}
+}
+
+func TestDictionaryWithCustomizedLocale(t *testing.T) {
+	loc := newLocale(lxn.Locale{
+		ID: "en-US",
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  lxn.Symbols{Zero: '0', Decimal: ".", Group: ","},
+			MaxFractionDigits:        2,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+	})
+	cat, err := newCatalog("en-US", []lxn.Message{
+		{
+			Key:          "total",
+			Text:         []string{"Total: ", ""},
+			Replacements: []lxn.Replacement{{Key: "n", TextPos: 1, Type: lxn.NumberReplacement}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dic, err := NewDictionary(loc, cat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	custom, err := loc.With(DecimalSeparator(","), GroupSeparator("."))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cdic, err := dic.WithLocale(custom)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := Context{"n": Float(1234.5)}
+	if got := cdic.Translate("", "total", ctx); got != "Total: 1.234,5" {
+		t.Errorf("unexpected translation with customized locale: %q", got)
+	}
+	if got := cdic.Ref("", "total").Translate(ctx); got != "Total: 1.234,5" {
+		t.Errorf("unexpected reference translation with customized locale: %q", got)
+	}
+	if got := dic.Translate("", "total", ctx); got != "Total: 1,234.5" {
+		t.Errorf("unexpected translation with base locale: %q", got)
+	}
+}
@@ -0,0 +1,502 @@
+package lxn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// ParseMode defines how strictly localized numbers are parsed.
+type ParseMode int
+
+const (
+	// ParseStrict requires the input to match one of the locale's number patterns.
+	// Group separators may be omitted, but if they are present, they have to be
+	// at the positions defined by the locale's grouping.
+	ParseStrict ParseMode = iota
+
+	// ParseLenient additionally accepts ASCII digits and signs, group separators
+	// at arbitrary positions, whitespace around the affixes, numbers without
+	// affixes, and exponents (e.g. "1.5E3").
+	ParseLenient
+)
+
+// maxFloatParseDigits is the maximum number of significant digits of a parsed
+// number which is returned as a Float. Every decimal number with up to 15
+// significant digits survives the round trip through a float64.
+const maxFloatParseDigits = 15
+
+// ParseError describes an input which could not be parsed as a localized number.
+// The position is the byte offset into the input where parsing failed.
+type ParseError struct {
+	Input string
+	Pos   int
+	Msg   string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("cannot parse %q at position %d: %s", e.Input, e.Pos, e.Msg)
+}
+
+// ParseNumber parses a number in the locale's decimal format, e.g. "1.234,56" in
+// German. An integer is returned as an Int if it fits into an int64. Other numbers
+// are returned as a Float if they have at most 15 significant digits, which is
+// the precision a float64 can hold exactly. All other numbers are returned as a
+// Decimal.
+func (l *Locale) ParseNumber(s string, mode ParseMode) (Variable, error) {
+	p := numberParser{input: s, mode: mode, loc: &l.loc}
+	d, _, err := p.parse(&l.loc.DecimalFormat)
+	if err != nil {
+		return nil, err
+	}
+	return parsedNumber(d), nil
+}
+
+// ParsePercent parses a number in the locale's percent format, e.g. "25 %" in
+// German. If the locale treats percent values as ratios (see WithPercentScaling),
+// the parsed value is scaled back, i.e. "25 %" results in 0.25. The type of the
+// returned value is chosen like for ParseNumber.
+func (l *Locale) ParsePercent(s string, mode ParseMode) (Variable, error) {
+	p := numberParser{input: s, mode: mode, loc: &l.loc}
+	d, _, err := p.parse(&l.loc.PercentFormat)
+	if err != nil {
+		return nil, err
+	}
+	if l.scalePercent {
+		d = d.shift(-2)
+	}
+	return parsedNumber(d), nil
+}
+
+// ParseMoney parses an amount of money in the locale's money or accounting format,
+// e.g. "1.234,56 €" in German. The currency is detected by its symbol, its narrow
+// symbol, or its ISO 4217 code. The amount's type is chosen like for ParseNumber.
+func (l *Locale) ParseMoney(s string, mode ParseMode) (Money, error) {
+	p := numberParser{input: s, mode: mode, loc: &l.loc}
+	formats := []*lxn.NumberFormat{&l.loc.MoneyFormat}
+	if l.loc.AccountingFormat.Symbols.Zero != 0 {
+		formats = append(formats, &l.loc.AccountingFormat)
+	}
+	d, currency, err := p.parse(formats...)
+	switch {
+	case err != nil:
+		return Money{}, err
+	case currency == "":
+		return Money{}, &ParseError{Input: s, Pos: 0, Msg: "missing currency"}
+	}
+	return Money{Amount: parsedNumber(d), Currency: currency}, nil
+}
+
+func parsedNumber(d Decimal) number {
+	if d.intLen >= len(d.coeff) && d.intLen <= 18 {
+		i := d.unscaled(0)
+		if i.IsInt64() {
+			return Int(i.Int64())
+		}
+	}
+	if len(d.coeff) <= maxFloatParseDigits {
+		f, err := strconv.ParseFloat(d.String(), 64)
+		if err == nil {
+			return Float(f)
+		}
+	}
+	return d
+}
+
+type numberParser struct {
+	input string
+	mode  ParseMode
+	loc   *lxn.Locale
+}
+
+// affixPair is a prefix and a suffix which enclose the digits of a number.
+type affixPair struct {
+	prefix string
+	suffix string
+	neg    bool
+}
+
+// parse parses the input with the patterns of the given number formats. It returns
+// the number and the currency code, if the pattern contains a currency placeholder.
+func (p *numberParser) parse(formats ...*lxn.NumberFormat) (Decimal, string, error) {
+	var furthest *ParseError
+	for _, nf := range formats {
+		pairs := []affixPair{
+			{prefix: nf.NegativePrefix, suffix: nf.NegativeSuffix, neg: true},
+			{prefix: nf.PositivePrefix, suffix: nf.PositiveSuffix},
+		}
+		if p.mode == ParseLenient {
+			pairs = append(pairs,
+				affixPair{prefix: string(minusPlaceholder), neg: true},
+				affixPair{prefix: string(plusPlaceholder)},
+				affixPair{},
+			)
+		}
+
+		for _, pair := range pairs {
+			d, currency, err := p.parseWith(nf, pair)
+			if err == nil {
+				return d, currency, nil
+			}
+			if furthest == nil || err.Pos > furthest.Pos {
+				furthest = err
+			}
+		}
+	}
+	return Decimal{}, "", furthest
+}
+
+func (p *numberParser) parseWith(nf *lxn.NumberFormat, pair affixPair) (Decimal, string, *ParseError) {
+	pos := 0
+	n, prefixCurrency, err := p.matchAffix(pos, pair.prefix, &nf.Symbols)
+	if err != nil {
+		return Decimal{}, "", err
+	}
+	pos += n
+
+	d, n, err := p.parseDigits(pos, nf)
+	if err != nil {
+		return Decimal{}, "", err
+	}
+	pos += n
+
+	n, suffixCurrency, err := p.matchAffix(pos, pair.suffix, &nf.Symbols)
+	if err != nil {
+		return Decimal{}, "", err
+	}
+	pos += n
+
+	if pos != len(p.input) {
+		return Decimal{}, "", p.errorf(pos, "unexpected character %q", p.runeAt(pos))
+	}
+
+	currency := prefixCurrency
+	if currency == "" {
+		currency = suffixCurrency
+	}
+	d.neg = pair.neg && !d.IsZero()
+	return d, currency, nil
+}
+
+// matchAffix matches the affix pattern at the given position and returns the
+// number of matched bytes as well as the currency code, if the pattern contains a
+// currency placeholder.
+func (p *numberParser) matchAffix(pos int, affix string, symb *lxn.Symbols) (int, string, *ParseError) {
+	start, currency := pos, ""
+	for _, ch := range affix {
+		if p.mode == ParseLenient {
+			if unicode.IsSpace(ch) {
+				continue
+			}
+			pos += p.skipSpace(pos)
+		}
+
+		var n int
+		switch ch {
+		case currencyPlaceholder:
+			code, cn, err := p.matchCurrency(pos)
+			if err != nil {
+				return 0, "", err
+			}
+			currency, n = code, cn
+		case minusPlaceholder:
+			n = p.matchSymbol(pos, symb.Minus, "-", "−")
+		case plusPlaceholder:
+			n = p.matchSymbol(pos, symb.Plus, "+")
+		case percentPlaceholder:
+			n = p.matchSymbol(pos, symb.Percent, "%")
+		case perMillePlaceholder:
+			n = p.matchSymbol(pos, symb.PerMille, "‰")
+		default:
+			n = p.matchSymbol(pos, string(ch))
+		}
+		if n < 0 {
+			if pos >= len(p.input) {
+				return 0, "", p.errorf(pos, "unexpected end of input")
+			}
+			return 0, "", p.errorf(pos, "unexpected character %q", p.runeAt(pos))
+		}
+		pos += n
+	}
+
+	if p.mode == ParseLenient {
+		pos += p.skipSpace(pos)
+	}
+	return pos - start, currency, nil
+}
+
+// matchSymbol matches the symbol at the given position. In lenient mode, the
+// alternatives are matched as well. It returns the number of matched bytes or -1
+// if nothing matches.
+func (p *numberParser) matchSymbol(pos int, symbol string, alternatives ...string) int {
+	if strings.HasPrefix(p.input[pos:], symbol) {
+		return len(symbol)
+	}
+	if p.mode == ParseLenient {
+		for _, alt := range alternatives {
+			if strings.HasPrefix(p.input[pos:], alt) {
+				return len(alt)
+			}
+		}
+	}
+	return -1
+}
+
+// matchCurrency matches an ISO 4217 code, a currency symbol, or a narrow symbol at
+// the given position, in this order of precedence. The narrow symbols are only
+// considered if neither a code nor a symbol matches, since they are often shared
+// between currencies (e.g. "$" for USD and CAD). Within each kind, the longest
+// match wins. If it is shared by several currencies, the match is ambiguous.
+func (p *numberParser) matchCurrency(pos int) (string, int, *ParseError) {
+	s := p.input[pos:]
+	symbols := [...]func(code string, curr *lxn.Currency) string{
+		func(code string, _ *lxn.Currency) string { return code },
+		func(_ string, curr *lxn.Currency) string { return curr.Symbol },
+		func(_ string, curr *lxn.Currency) string { return curr.NarrowSymbol },
+	}
+	for i, symbolOf := range symbols {
+		code, n, ambiguous := "", 0, false
+		for c, curr := range p.loc.Currencies {
+			switch symbol := symbolOf(c, &curr); {
+			case symbol == "" || !strings.HasPrefix(s, symbol) || len(symbol) < n:
+			case len(symbol) > n:
+				code, n, ambiguous = c, len(symbol), false
+			case c != code:
+				ambiguous = true
+			}
+		}
+		if i == 0 && n == 0 && isCurrencyCode(s) {
+			code, n = s[:3], 3
+		}
+
+		switch {
+		case ambiguous:
+			return "", 0, p.errorf(pos, "ambiguous currency symbol %q", s[:n])
+		case n > 0:
+			return code, n, nil
+		}
+	}
+	return "", 0, p.errorf(pos, "missing currency")
+}
+
+func isCurrencyCode(s string) bool {
+	if len(s) < 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return len(s) == 3 || !unicode.IsLetter(rune(s[3]))
+}
+
+// parseDigits parses the integer digits, the fraction digits, and an optional
+// exponent (lenient mode only) at the given position. It returns the number and
+// the number of parsed bytes. Numbers whose magnitude exceeds 10^±10000 are
+// rejected.
+func (p *numberParser) parseDigits(pos int, nf *lxn.NumberFormat) (Decimal, int, *ParseError) {
+	start := pos
+	coeff := make([]byte, 0, len(p.input)-pos)
+	intLen := 0
+
+	// integer digits with group separators
+	var (
+		groups   []int // number of integer digits preceding each group separator
+		groupPos []int // input positions of the group separators
+	)
+	for pos < len(p.input) {
+		if d, n := p.digit(pos, nf); n > 0 {
+			coeff = append(coeff, d)
+			intLen++
+			pos += n
+			continue
+		}
+		n := p.groupSeparator(pos, &nf.Symbols)
+		if n == 0 || intLen == 0 {
+			break
+		}
+		if _, dn := p.digit(pos+n, nf); dn == 0 {
+			break // not followed by a digit
+		}
+		groups = append(groups, intLen)
+		groupPos = append(groupPos, pos)
+		pos += n
+	}
+	if p.mode == ParseStrict {
+		if i := invalidGroup(groups, intLen, nf); i >= 0 {
+			return Decimal{}, 0, p.errorf(groupPos[i], "misplaced group separator")
+		}
+	}
+
+	// fraction digits
+	if nf.Symbols.Decimal != "" && strings.HasPrefix(p.input[pos:], nf.Symbols.Decimal) {
+		fracStart := pos
+		pos += len(nf.Symbols.Decimal)
+		numFrac := 0
+		for pos < len(p.input) {
+			d, n := p.digit(pos, nf)
+			if n == 0 {
+				break
+			}
+			coeff = append(coeff, d)
+			numFrac++
+			pos += n
+		}
+		if intLen == 0 && numFrac == 0 {
+			return Decimal{}, 0, p.errorf(fracStart, "missing digits")
+		}
+	}
+	if len(coeff) == 0 {
+		if pos >= len(p.input) {
+			return Decimal{}, 0, p.errorf(pos, "unexpected end of input")
+		}
+		return Decimal{}, 0, p.errorf(pos, "unexpected character %q", p.runeAt(pos))
+	}
+
+	// The locale's patterns have no exponent, so it is only accepted in lenient
+	// mode.
+	if p.mode == ParseLenient {
+		e, n, err := p.parseExponent(pos, nf)
+		if err != nil {
+			return Decimal{}, 0, err
+		}
+		intLen += e
+		pos += n
+	}
+
+	d := newDecimal(false, coeff, intLen)
+	if d.intLen > maxDecimalExponent || d.intLen < -maxDecimalExponent {
+		return Decimal{}, 0, p.errorf(start, "number out of range")
+	}
+	return d, pos - start, nil
+}
+
+// parseExponent parses an exponent at the given position, e.g. "E-3". It returns
+// the exponent and the number of consumed bytes. The exponential symbol is only
+// treated as an exponent if it is followed by digits, so "5EUR" is not parsed as
+// a broken exponent.
+func (p *numberParser) parseExponent(pos int, nf *lxn.NumberFormat) (int, int, *ParseError) {
+	exp := nf.Symbols.Exponential
+	if exp == "" || !strings.HasPrefix(p.input[pos:], exp) {
+		return 0, 0, nil
+	}
+
+	start := pos
+	pos += len(exp)
+	neg := false
+	if n := p.matchSymbol(pos, nf.Symbols.Minus, "-", "−"); n > 0 {
+		neg, pos = true, pos+n
+	} else if n := p.matchSymbol(pos, nf.Symbols.Plus, "+"); n > 0 {
+		pos += n
+	}
+	if _, n := p.digit(pos, nf); n == 0 {
+		return 0, 0, nil
+	}
+
+	e := 0
+	for {
+		d, n := p.digit(pos, nf)
+		if n == 0 {
+			break
+		}
+		e = 10*e + int(d-'0')
+		if e > maxDecimalExponent {
+			return 0, 0, p.errorf(start, "exponent out of range")
+		}
+		pos += n
+	}
+	if neg {
+		e = -e
+	}
+	return e, pos - start, nil
+}
+
+// digit returns the ASCII digit at the given position and the number of bytes it
+// occupies. If there is no digit, zero bytes are returned.
+func (p *numberParser) digit(pos int, nf *lxn.NumberFormat) (byte, int) {
+	if pos >= len(p.input) {
+		return 0, 0
+	}
+	r, n := utf8.DecodeRuneInString(p.input[pos:])
+	zero := rune(nf.Symbols.Zero)
+	switch {
+	case zero <= r && r <= zero+9:
+		return byte('0' + r - zero), n
+	case p.mode == ParseLenient && '0' <= r && r <= '9':
+		return byte(r), n
+	}
+	return 0, 0
+}
+
+// groupSeparator returns the number of bytes of the group separator at the given
+// position. In lenient mode, any whitespace is accepted if the group separator is
+// a whitespace itself.
+func (p *numberParser) groupSeparator(pos int, symb *lxn.Symbols) int {
+	if symb.Group != "" && strings.HasPrefix(p.input[pos:], symb.Group) {
+		return len(symb.Group)
+	}
+	if p.mode == ParseLenient && pos < len(p.input) {
+		group, _ := utf8.DecodeRuneInString(symb.Group)
+		r, n := utf8.DecodeRuneInString(p.input[pos:])
+		if unicode.IsSpace(group) && unicode.IsSpace(r) {
+			return n
+		}
+	}
+	return 0
+}
+
+// invalidGroup returns the index of the first group separator which is not at a
+// position defined by the number format, or -1 if all separators are valid. The
+// groups are given as the number of integer digits preceding each separator.
+func invalidGroup(groups []int, intLen int, nf *lxn.NumberFormat) int {
+	if len(groups) == 0 {
+		return -1
+	}
+	primary, secondary := nf.PrimaryIntegerGrouping, nf.SecondaryIntegerGrouping
+	if primary <= 0 || intLen < primary+max(nf.MinGroupingDigits, 1) {
+		return 0
+	}
+	if secondary <= 0 {
+		secondary = primary
+	}
+
+	// walk the separators from right to left
+	expected := intLen - primary
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] != expected {
+			return i
+		}
+		expected -= secondary
+	}
+	if expected > 0 {
+		// the leading group is too large
+		return 0
+	}
+	return -1
+}
+
+func (p *numberParser) skipSpace(pos int) int {
+	start := pos
+	for pos < len(p.input) {
+		r, n := utf8.DecodeRuneInString(p.input[pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		pos += n
+	}
+	return pos - start
+}
+
+func (p *numberParser) runeAt(pos int) rune {
+	r, _ := utf8.DecodeRuneInString(p.input[pos:])
+	return r
+}
+
+func (p *numberParser) errorf(pos int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Input: p.input, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
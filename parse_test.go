@@ -0,0 +1,219 @@
+package lxn
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func parseTestLocale() *Locale {
+	symbols := lxn.Symbols{
+		Zero:        '0',
+		Decimal:     ",",
+		Group:       ".",
+		Minus:       "-",
+		Plus:        "+",
+		Percent:     "%",
+		Exponential: "E",
+	}
+
+	return newLocale(lxn.Locale{
+		ID: "de",
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:                  symbols,
+			NegativePrefix:           "-",
+			MaxFractionDigits:        3,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		PercentFormat: lxn.NumberFormat{
+			Symbols:                  symbols,
+			PositiveSuffix:           " %",
+			NegativePrefix:           "-",
+			NegativeSuffix:           " %",
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		MoneyFormat: lxn.NumberFormat{
+			Symbols:                  symbols,
+			PositiveSuffix:           " ¤",
+			NegativePrefix:           "-",
+			NegativeSuffix:           " ¤",
+			MinFractionDigits:        2,
+			MaxFractionDigits:        2,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		AccountingFormat: lxn.NumberFormat{
+			Symbols:                  symbols,
+			PositiveSuffix:           " ¤",
+			NegativePrefix:           "(",
+			NegativeSuffix:           " ¤)",
+			MinFractionDigits:        2,
+			MaxFractionDigits:        2,
+			PrimaryIntegerGrouping:   3,
+			SecondaryIntegerGrouping: 3,
+		},
+		Currencies: map[string]lxn.Currency{
+			"EUR": {Symbol: "€", FractionDigits: 2},
+			"USD": {Symbol: "$", NarrowSymbol: "$", FractionDigits: 2},
+			"CAD": {Symbol: "CA$", NarrowSymbol: "$", FractionDigits: 2},
+			"AUD": {Symbol: "A$", NarrowSymbol: "$", FractionDigits: 2},
+			"JPY": {Symbol: "JP¥", NarrowSymbol: "¥"},
+			"CNY": {Symbol: "CN¥", NarrowSymbol: "¥", FractionDigits: 2},
+			"PLN": {Symbol: "PLN", NarrowSymbol: "zł", FractionDigits: 2},
+		},
+	})
+}
+
+func TestLocaleParseNumber(t *testing.T) {
+	loc := parseTestLocale()
+
+	tests := []struct {
+		input    string
+		mode     ParseMode
+		expected Variable
+	}{
+		{input: "0", mode: ParseStrict, expected: Int(0)},
+		{input: "-0", mode: ParseStrict, expected: Int(0)},
+		{input: "1234", mode: ParseStrict, expected: Int(1234)},
+		{input: "1.234", mode: ParseStrict, expected: Int(1234)},
+		{input: "-1.234.567", mode: ParseStrict, expected: Int(-1234567)},
+		{input: "1.234,56", mode: ParseStrict, expected: Float(1234.56)},
+		{input: ",5", mode: ParseStrict, expected: Float(0.5)},
+		{input: "9.223.372.036.854.775.808", mode: ParseStrict, expected: mustParseDecimal("9223372036854775808")},
+		{input: "0,1234567890123456", mode: ParseStrict, expected: mustParseDecimal("0.1234567890123456")},
+		{input: " 12.34,5 ", mode: ParseLenient, expected: Float(1234.5)},
+		{input: "+7", mode: ParseLenient, expected: Int(7)},
+		{input: "−7", mode: ParseLenient, expected: Int(-7)},
+		{input: "1,5E3", mode: ParseLenient, expected: Int(1500)},
+		{input: "1,5E-3", mode: ParseLenient, expected: Float(0.0015)},
+		{input: "1E9999", mode: ParseLenient, expected: mustParseDecimal("1e9999")},
+	}
+
+	for _, test := range tests {
+		v, err := loc.ParseNumber(test.input, test.mode)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.input, err)
+		case v != test.expected:
+			t.Errorf("unexpected number for %q: %#v", test.input, v)
+		}
+	}
+}
+
+func TestLocaleParseNumberErrors(t *testing.T) {
+	loc := parseTestLocale()
+
+	tests := []struct {
+		input string
+		mode  ParseMode
+		pos   int
+	}{
+		{input: "", mode: ParseStrict, pos: 0},
+		{input: "abc", mode: ParseStrict, pos: 0},
+		{input: "12a", mode: ParseStrict, pos: 2},
+		{input: "12.34", mode: ParseStrict, pos: 2},
+		{input: "1234.567", mode: ParseStrict, pos: 4},
+		{input: "1,2,3", mode: ParseStrict, pos: 3},
+		{input: " 12", mode: ParseStrict, pos: 0},
+		{input: "+7", mode: ParseStrict, pos: 0},
+		{input: "1,5E", mode: ParseStrict, pos: 3},
+		{input: "1,5E3", mode: ParseStrict, pos: 3},
+		{input: "1,5E", mode: ParseLenient, pos: 3},
+		{input: "1E9999999", mode: ParseLenient, pos: 1},
+		{input: "1E10000", mode: ParseLenient, pos: 0},
+		{input: "1E-10001", mode: ParseLenient, pos: 1},
+		{input: "0,01E-10000", mode: ParseLenient, pos: 0},
+		{input: "12 a", mode: ParseLenient, pos: 3},
+	}
+
+	for _, test := range tests {
+		_, err := loc.ParseNumber(test.input, test.mode)
+		var perr *ParseError
+		switch {
+		case err == nil:
+			t.Errorf("expected error for %q", test.input)
+		case !errors.As(err, &perr):
+			t.Errorf("unexpected error type for %q: %T", test.input, err)
+		case perr.Pos != test.pos:
+			t.Errorf("unexpected error position for %q: %d (%v)", test.input, perr.Pos, err)
+		}
+	}
+}
+
+func TestLocaleParsePercent(t *testing.T) {
+	loc := parseTestLocale()
+
+	tests := []struct {
+		input    string
+		mode     ParseMode
+		scaling  bool
+		expected Variable
+	}{
+		{input: "25 %", mode: ParseStrict, expected: Int(25)},
+		{input: "-25 %", mode: ParseStrict, expected: Int(-25)},
+		{input: "25 %", mode: ParseStrict, scaling: true, expected: Float(0.25)},
+		{input: "25 %", mode: ParseLenient, expected: Int(25)},
+		{input: "25%", mode: ParseLenient, expected: Int(25)},
+		{input: "25", mode: ParseLenient, expected: Int(25)},
+	}
+
+	for _, test := range tests {
+		l := loc
+		if test.scaling {
+			l = l.WithPercentScaling()
+		}
+
+		v, err := l.ParsePercent(test.input, test.mode)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.input, err)
+		case v != test.expected:
+			t.Errorf("unexpected percent for %q: %#v", test.input, v)
+		}
+	}
+
+	if _, err := loc.ParsePercent("25", ParseStrict); err == nil {
+		t.Errorf("expected error for missing percent sign")
+	}
+}
+
+func TestLocaleParseMoney(t *testing.T) {
+	loc := parseTestLocale()
+
+	tests := []struct {
+		input    string
+		mode     ParseMode
+		expected Money
+	}{
+		{input: "1.234,56 €", mode: ParseStrict, expected: Money{Amount: Float(1234.56), Currency: "EUR"}},
+		{input: "-1,50 EUR", mode: ParseStrict, expected: Money{Amount: Float(-1.5), Currency: "EUR"}},
+		{input: "(1,50 €)", mode: ParseStrict, expected: Money{Amount: Float(-1.5), Currency: "EUR"}},
+		{input: "3 CA$", mode: ParseStrict, expected: Money{Amount: Int(3), Currency: "CAD"}},
+		{input: "3 $", mode: ParseStrict, expected: Money{Amount: Int(3), Currency: "USD"}},
+		{input: "3 JPY", mode: ParseStrict, expected: Money{Amount: Int(3), Currency: "JPY"}},
+		{input: "3 JP¥", mode: ParseStrict, expected: Money{Amount: Int(3), Currency: "JPY"}},
+		{input: "3 CHF", mode: ParseStrict, expected: Money{Amount: Int(3), Currency: "CHF"}},
+		{input: "3 €", mode: ParseLenient, expected: Money{Amount: Int(3), Currency: "EUR"}},
+		{input: "5EUR", mode: ParseLenient, expected: Money{Amount: Int(5), Currency: "EUR"}},
+	}
+
+	for _, test := range tests {
+		m, err := loc.ParseMoney(test.input, test.mode)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.input, err)
+		case m != test.expected:
+			t.Errorf("unexpected money for %q: %#v", test.input, m)
+		}
+	}
+
+	invalid := []string{"3 ¥", "3", "3 XY"}
+	for _, input := range invalid {
+		if _, err := loc.ParseMoney(input, ParseLenient); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package lxn
+
+import (
+	"strings"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// percentNumber scales the number according to the percent scale and returns the
+// number format to use. For the thousand scale, the percent signs in the format's
+// affixes are replaced by per-mille signs.
+func percentNumber(num number, nf *lxn.NumberFormat, scale lxn.PercentScale) (number, *lxn.NumberFormat) {
+	switch scale {
+	case lxn.PercentScaleHundred:
+		return scaleNumber(num, 2), nf
+	case lxn.PercentScaleThousand:
+		pnf := perMilleFormat(nf)
+		return scaleNumber(num, 3), &pnf
+	default:
+		return num, nf
+	}
+}
+
+// scaleNumber multiplies the number with 10^n. The multiplication is done on the
+// decimal representation of the number, i.e. Float(0.07) becomes 7 and not
+// 7.000000000000001. Floating-point numbers which are not finite are returned
+// as is.
+func scaleNumber(num number, n int) number {
+	if d, ok := toDecimal(num); ok {
+		return d.shift(n)
+	}
+	return num
+}
+
+func perMilleFormat(nf *lxn.NumberFormat) lxn.NumberFormat {
+	percent, perMille := string(percentPlaceholder), string(perMillePlaceholder)
+	pnf := *nf
+	pnf.PositivePrefix = strings.ReplaceAll(pnf.PositivePrefix, percent, perMille)
+	pnf.PositiveSuffix = strings.ReplaceAll(pnf.PositiveSuffix, percent, perMille)
+	pnf.NegativePrefix = strings.ReplaceAll(pnf.NegativePrefix, percent, perMille)
+	pnf.NegativeSuffix = strings.ReplaceAll(pnf.NegativeSuffix, percent, perMille)
+	return pnf
+}
@@ -0,0 +1,194 @@
+package lxn
+
+import (
+	"sync/atomic"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// replacementSize is the estimated size of a formatted replacement, which is used
+// to preallocate the output of a message.
+const replacementSize = 16
+
+// plan is the compiled form of a message. A plan is built once when a message is
+// loaded: adjacent static texts are concatenated, the replacement details are
+// resolved, and the variants of nested messages are compiled as well. Formatting
+// a message only needs to execute the plan's steps.
+type plan struct {
+	steps []step
+	size  int // estimated size of the formatted message
+}
+
+// step is a single formatting step, which either writes a static text or a
+// replacement.
+type step struct {
+	text string
+	repl *replacement // nil for static text
+}
+
+// replacement is a compiled replacement with its details resolved according to
+// the replacement type.
+type replacement struct {
+	key       string
+	typ       lxn.ReplacementType
+	corrupted bool // the details do not match the replacement type
+
+	number   *lxn.NumberDetails // number and percent replacements
+	money    *lxn.MoneyDetails
+	time     *lxn.TimeDetails
+	plural   *pluralPlan
+	sel      *selectPlan
+	skeleton skeleton
+
+	timePattern atomic.Pointer[timePatternEntry] // pattern of the last locale
+}
+
+// timePatternEntry is the resolved date/time pattern of a time replacement for a
+// locale.
+type timePatternEntry struct {
+	loc     *Locale
+	pattern string
+	err     error
+}
+
+// pluralPlan holds the compiled variants of a plural replacement.
+type pluralPlan struct {
+	typ      lxn.PluralType
+	percent  bool
+	scale    lxn.PercentScale
+	variants map[lxn.PluralCategory]*plan
+	custom   map[int64]*plan
+	other    *plan // variant for the other category, nil if there is none
+}
+
+// selectPlan holds the compiled cases of a select replacement.
+type selectPlan struct {
+	cases    map[string]*plan
+	fallback *plan // case for the fallback key, nil if there is none
+}
+
+func compile(m *lxn.Message) *plan {
+	p := &plan{}
+	off := 0
+	for i, t := range m.Text {
+		for off < len(m.Replacements) && m.Replacements[off].TextPos <= i {
+			p.addReplacement(&m.Replacements[off])
+			off++
+		}
+		p.addText(t)
+	}
+	for i := off; i < len(m.Replacements); i++ {
+		p.addReplacement(&m.Replacements[i])
+	}
+	return p
+}
+
+func (p *plan) addText(text string) {
+	if text == "" {
+		return
+	}
+
+	p.size += len(text)
+	if n := len(p.steps); n > 0 && p.steps[n-1].repl == nil {
+		p.steps[n-1].text += text
+		return
+	}
+	p.steps = append(p.steps, step{text: text})
+}
+
+func (p *plan) addReplacement(r *lxn.Replacement) {
+	p.size += replacementSize
+	p.steps = append(p.steps, step{repl: compileReplacement(r)})
+}
+
+func (p *plan) format(w *writer, ctx Context, loc *Locale) {
+	for i := range p.steps {
+		if s := &p.steps[i]; s.repl == nil {
+			w.WriteString(s.text)
+		} else {
+			s.repl.format(w, ctx, loc)
+		}
+	}
+}
+
+func compileReplacement(r *lxn.Replacement) *replacement {
+	repl := &replacement{key: r.Key, typ: r.Type}
+
+	var err error
+	switch r.Type {
+	case lxn.NumberReplacement, lxn.PercentReplacement:
+		details, _ := r.Details.Value.(lxn.NumberDetails) // details are optional
+		repl.number = &details
+		repl.skeleton, err = parseSkeleton(details.Skeleton)
+
+	case lxn.MoneyReplacement:
+		details, ok := r.Details.Value.(lxn.MoneyDetails)
+		if !ok {
+			repl.corrupted = true
+			break
+		}
+		repl.money = &details
+		repl.skeleton, err = parseSkeleton(details.Skeleton)
+
+	case lxn.PluralReplacement:
+		details, ok := r.Details.Value.(lxn.PluralDetails)
+		if !ok {
+			repl.corrupted = true
+			break
+		}
+		repl.plural = compilePlural(&details)
+
+	case lxn.SelectReplacement:
+		details, ok := r.Details.Value.(lxn.SelectDetails)
+		if !ok {
+			repl.corrupted = true
+			break
+		}
+		repl.sel = compileSelect(&details)
+
+	case lxn.TimeReplacement:
+		details, ok := r.Details.Value.(lxn.TimeDetails)
+		if !ok {
+			repl.corrupted = true
+			break
+		}
+		repl.time = &details
+		_, err = parseTimeSkeleton(details.Skeleton)
+	}
+
+	if err != nil {
+		repl.corrupted = true
+	}
+	return repl
+}
+
+func compilePlural(details *lxn.PluralDetails) *pluralPlan {
+	p := &pluralPlan{
+		typ:      details.Type,
+		percent:  details.Percent,
+		scale:    details.PercentScale,
+		variants: make(map[lxn.PluralCategory]*plan, len(details.Variants)),
+	}
+	for cat, msg := range details.Variants {
+		p.variants[cat] = compile(&msg)
+	}
+	if len(details.Custom) != 0 {
+		p.custom = make(map[int64]*plan, len(details.Custom))
+		for n, msg := range details.Custom {
+			p.custom[n] = compile(&msg)
+		}
+	}
+	p.other = p.variants[lxn.Other]
+	return p
+}
+
+func compileSelect(details *lxn.SelectDetails) *selectPlan {
+	p := &selectPlan{
+		cases: make(map[string]*plan, len(details.Cases)),
+	}
+	for key, msg := range details.Cases {
+		p.cases[key] = compile(&msg)
+	}
+	p.fallback = p.cases[details.Fallback]
+	return p
+}
@@ -0,0 +1,79 @@
+package lxn
+
+import (
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		msg     lxn.Message
+		texts   []string // static text per step, "" for replacements
+		corrupt []bool
+	}{
+		{
+			msg:   lxn.Message{Text: []string{"foo", "", "bar"}},
+			texts: []string{"foobar"},
+		},
+		{
+			msg: lxn.Message{
+				Text: []string{"foo ", " bar", " baz"},
+				Replacements: []lxn.Replacement{
+					{Key: "a", TextPos: 0, Type: lxn.StringReplacement},
+					{Key: "b", TextPos: 2, Type: lxn.NumberReplacement},
+					{Key: "c", TextPos: 5, Type: lxn.MoneyReplacement},
+				},
+			},
+			texts:   []string{"", "foo  bar", "", " baz", ""},
+			corrupt: []bool{false, false, false, false, true},
+		},
+		{
+			msg: lxn.Message{
+				Replacements: []lxn.Replacement{
+					{
+						Key:  "a",
+						Type: lxn.NumberReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.NumberDetails{Skeleton: "foo"},
+						},
+					},
+					{
+						Key:  "b",
+						Type: lxn.PluralReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.SelectDetails{},
+						},
+					},
+					{
+						Key:  "c",
+						Type: lxn.TimeReplacement,
+						Details: lxn.ReplacementDetails{
+							Value: lxn.TimeDetails{Skeleton: "yw"},
+						},
+					},
+				},
+			},
+			texts:   []string{"", "", ""},
+			corrupt: []bool{true, true, true},
+		},
+	}
+
+	for _, test := range tests {
+		p := compile(&test.msg)
+		if len(p.steps) != len(test.texts) {
+			t.Errorf("unexpected number of steps for %q: %d", test.msg.Text, len(p.steps))
+			continue
+		}
+		for i, s := range p.steps {
+			switch {
+			case s.repl == nil && s.text != test.texts[i]:
+				t.Errorf("unexpected text in step %d for %q: %q", i, test.msg.Text, s.text)
+			case s.repl != nil && test.texts[i] != "":
+				t.Errorf("unexpected replacement in step %d for %q", i, test.msg.Text)
+			case s.repl != nil && s.repl.corrupted != test.corrupt[i]:
+				t.Errorf("unexpected corruption in step %d for %q: %v", i, test.msg.Text, s.repl.corrupted)
+			}
+		}
+	}
+}
@@ -1,14 +1,166 @@
 package lxn
 
 import (
+	"math"
+	"strconv"
+	"strings"
+
 	"github.com/liblxn/lxn-go/internal/lxn"
 )
 
+// PluralCategory is a CLDR plural category.
+type PluralCategory int
+
+// Plural categories as defined by CLDR.
+const (
+	PluralZero  = PluralCategory(lxn.Zero)
+	PluralOne   = PluralCategory(lxn.One)
+	PluralTwo   = PluralCategory(lxn.Two)
+	PluralFew   = PluralCategory(lxn.Few)
+	PluralMany  = PluralCategory(lxn.Many)
+	PluralOther = PluralCategory(lxn.Other)
+)
+
+var pluralCategoryNames = [...]string{
+	lxn.Zero:  "zero",
+	lxn.One:   "one",
+	lxn.Two:   "two",
+	lxn.Few:   "few",
+	lxn.Many:  "many",
+	lxn.Other: "other",
+}
+
+// String returns the CLDR name of the category, e.g. "one".
+func (c PluralCategory) String() string {
+	if 0 <= c && int(c) < len(pluralCategoryNames) {
+		return pluralCategoryNames[c]
+	}
+	return "PluralCategory(" + strconv.Itoa(int(c)) + ")"
+}
+
+// PluralRule is the rule of a plural category. The condition is given in the CLDR
+// plural rule syntax, e.g. "i = 1 and v = 0". The category "other" has no rule,
+// since it applies to all numbers which do not match any other category.
+type PluralRule struct {
+	Category  PluralCategory
+	Condition string
+}
+
+// PluralCategory returns the cardinal or ordinal plural category of the variable,
+// e.g. PluralFew for 21 in Russian. The number is formatted with the locale's
+// decimal format first, so visible fraction digits affect the category. Variables
+// which are not numeric belong to the category PluralOther.
+func (l *Locale) PluralCategory(v Variable, ordinal bool) PluralCategory {
+	num, isNum := v.(number)
+	if !isNum {
+		return PluralOther
+	}
+	return PluralCategory(pluralTag(num, &l.loc.DecimalFormat, l.plurals(ordinal)))
+}
+
+// PluralCategories returns the cardinal or ordinal plural categories used by the
+// locale in ascending order. The category PluralOther is always included.
+func (l *Locale) PluralCategories(ordinal bool) []PluralCategory {
+	var used [len(pluralCategoryNames)]bool
+	used[PluralOther] = true
+	for _, p := range l.plurals(ordinal) {
+		if 0 <= p.Category && int(p.Category) < len(used) {
+			used[p.Category] = true
+		}
+	}
+
+	categories := make([]PluralCategory, 0, len(used))
+	for c, ok := range used {
+		if ok {
+			categories = append(categories, PluralCategory(c))
+		}
+	}
+	return categories
+}
+
+func (l *Locale) plurals(ordinal bool) []lxn.Plural {
+	if ordinal {
+		return l.loc.OrdinalPlurals
+	}
+	return l.loc.CardinalPlurals
+}
+
+func pluralRules(plurals []lxn.Plural) []PluralRule {
+	rules := make([]PluralRule, 0, len(plurals))
+	for _, p := range plurals {
+		rules = append(rules, PluralRule{
+			Category:  PluralCategory(p.Category),
+			Condition: pluralCondition(p.Rules),
+		})
+	}
+	return rules
+}
+
+var pluralOperandNames = [...]string{
+	lxn.AbsoluteValue:        "n",
+	lxn.IntegerDigits:        "i",
+	lxn.NumFracDigits:        "v",
+	lxn.NumFracDigitsNoZeros: "w",
+	lxn.FracDigits:           "f",
+	lxn.FracDigitsNoZeros:    "t",
+	lxn.CompactDecExponent:   "c",
+}
+
+// pluralCondition returns the rules in the CLDR plural rule syntax.
+func pluralCondition(rules []lxn.PluralRule) string {
+	var sb strings.Builder
+	for i, r := range rules {
+		if i > 0 {
+			switch rules[i-1].Connective {
+			case lxn.Conjunction:
+				sb.WriteString(" and ")
+			default:
+				sb.WriteString(" or ")
+			}
+		}
+
+		if 0 <= r.Operand && int(r.Operand) < len(pluralOperandNames) {
+			sb.WriteString(pluralOperandNames[r.Operand])
+		} else {
+			sb.WriteByte('?')
+		}
+		if r.Modulo > 0 {
+			sb.WriteString(" % ")
+			sb.WriteString(strconv.Itoa(r.Modulo))
+		}
+		if r.Negate {
+			sb.WriteString(" != ")
+		} else {
+			sb.WriteString(" = ")
+		}
+		for j, rng := range r.Ranges {
+			if j > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.Itoa(rng.LowerBound))
+			if rng.UpperBound != rng.LowerBound {
+				sb.WriteString("..")
+				sb.WriteString(strconv.Itoa(rng.UpperBound))
+			}
+		}
+	}
+	return sb.String()
+}
+
 func pluralTag(num number, nf *lxn.NumberFormat, plurals []lxn.Plural) lxn.PluralCategory {
+	return compactPluralTag(num, nf, plurals, 0)
+}
+
+// compactPluralTag returns the plural category for a number which is displayed in
+// a compact form, e.g. 1.2M for 1200000. The exponent is the power of ten which
+// was used to compact the number (6 in the example). The number itself needs to
+// be the uncompacted value.
+func compactPluralTag(num number, nf *lxn.NumberFormat, plurals []lxn.Plural, exponent int) lxn.PluralCategory {
 	var buf [maxFloatDigits]rune
 
 	intDigits, fracDigits := num.digits(buf[:], nf, 0)
 	op := newOperands(intDigits, fracDigits)
+	op.c = int64(exponent)
 
 	for _, p := range plurals {
 		match := false
@@ -33,34 +185,51 @@ func pluralTag(num number, nf *lxn.NumberFormat, plurals []lxn.Plural) lxn.Plura
 	return lxn.Other
 }
 
+// maxOperandDigits is the maximum number of digits an operand can hold without
+// overflowing. Operands with more digits keep their trailing digits only, which
+// is sufficient to calculate the modulo for a power of ten.
+const maxOperandDigits = 18
+
 type operands struct {
 	i int64
 	v int64
 	f int64
 	w int64
 	t int64
+	c int64
+
+	iTruncated bool
+	fTruncated bool
 }
 
 func newOperands(intDigits []rune, fracDigits []rune) operands {
-	digitsValue := func(digits []rune) (val int64) {
+	digitsValue := func(digits []rune) (val int64, truncated bool) {
+		if len(digits) > maxOperandDigits {
+			digits, truncated = digits[len(digits)-maxOperandDigits:], true
+		}
 		for _, d := range digits {
 			val *= 10
 			val += int64(d)
 		}
-		return val
+		return val, truncated
 	}
 
+	i, iTruncated := digitsValue(intDigits)
+	f, fTruncated := digitsValue(fracDigits)
 	return operands{
-		i: digitsValue(intDigits),
-		f: digitsValue(fracDigits),
-		v: int64(len(fracDigits)),
-		w: -1, // lazily calculated
-		t: -1, // lazily calculated
+		i:          i,
+		f:          f,
+		v:          int64(len(fracDigits)),
+		w:          -1, // lazily calculated
+		t:          -1, // lazily calculated
+		iTruncated: iTruncated,
+		fTruncated: fTruncated,
 	}
 }
 
 func (op *operands) matchRule(r *lxn.PluralRule, fracDigits []rune) bool {
 	var x int64
+	truncated := false
 	switch r.Operand {
 	case lxn.AbsoluteValue: // n
 		// Since the ranges contain integer values only, we do not match
@@ -71,7 +240,7 @@ func (op *operands) matchRule(r *lxn.PluralRule, fracDigits []rune) bool {
 		fallthrough
 
 	case lxn.IntegerDigits: // i
-		x = op.i
+		x, truncated = op.i, op.iTruncated
 
 	case lxn.NumFracDigits: // v
 		x = op.v
@@ -86,7 +255,7 @@ func (op *operands) matchRule(r *lxn.PluralRule, fracDigits []rune) bool {
 		x = op.w
 
 	case lxn.FracDigits: // f
-		x = op.f
+		x, truncated = op.f, op.fTruncated
 
 	case lxn.FracDigitsNoZeros: // t
 		if op.t < 0 {
@@ -95,7 +264,10 @@ func (op *operands) matchRule(r *lxn.PluralRule, fracDigits []rune) bool {
 				op.t /= 10
 			}
 		}
-		x = op.t
+		x, truncated = op.t, op.fTruncated
+
+	case lxn.CompactDecExponent: // c, e
+		x = op.c
 
 	default:
 		return r.Negate // ignore unknown operands
@@ -103,6 +275,9 @@ func (op *operands) matchRule(r *lxn.PluralRule, fracDigits []rune) bool {
 
 	if r.Modulo > 0 {
 		x %= int64(r.Modulo)
+	} else if truncated {
+		// The operand exceeds all ranges.
+		x = math.MaxInt64
 	}
 
 	for _, rng := range r.Ranges {
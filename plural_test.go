@@ -1,6 +1,7 @@
 package lxn
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/liblxn/lxn-go/internal/lxn"
@@ -76,6 +77,26 @@ func TestPluralTag(t *testing.T) {
 		},
 
 		"integer digits": {
+			{
+				num: Float(1.5),
+				nf: lxn.NumberFormat{
+					MaxFractionDigits: 0,
+					RoundingMode:      lxn.RoundDown,
+				},
+				plurals: []lxn.Plural{
+					{
+						Category: lxn.One,
+						Rules: []lxn.PluralRule{
+							{
+								Operand:    lxn.IntegerDigits,
+								Ranges:     []lxn.Range{{LowerBound: 1, UpperBound: 1}},
+								Connective: lxn.None,
+							},
+						},
+					},
+				},
+				expected: lxn.One,
+			},
 			{
 				num: Float(7.5),
 				nf: lxn.NumberFormat{
@@ -135,6 +156,26 @@ func TestPluralTag(t *testing.T) {
 				},
 				expected: lxn.Many,
 			},
+			{
+				num: mustParseDecimal("12345678901234567895.25"),
+				nf: lxn.NumberFormat{
+					MaxFractionDigits: 2,
+				},
+				plurals: []lxn.Plural{
+					{
+						Category: lxn.Many,
+						Rules: []lxn.PluralRule{
+							{
+								Operand:    lxn.IntegerDigits,
+								Modulo:     10,
+								Ranges:     []lxn.Range{{LowerBound: 5, UpperBound: 5}},
+								Connective: lxn.None,
+							},
+						},
+					},
+				},
+				expected: lxn.Many,
+			},
 		},
 
 		"number of fraction digits with trailing zeros": {
@@ -808,3 +849,104 @@ func TestPluralTag(t *testing.T) {
 		})
 	}
 }
+
+// russianPlurals are the cardinal plural rules for Russian.
+var russianPlurals = []lxn.Plural{
+	{
+		Category: lxn.One,
+		Rules: []lxn.PluralRule{
+			{Operand: lxn.NumFracDigits, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+			{Operand: lxn.IntegerDigits, Modulo: 10, Ranges: []lxn.Range{{LowerBound: 1, UpperBound: 1}}, Connective: lxn.Conjunction},
+			{Operand: lxn.IntegerDigits, Modulo: 100, Negate: true, Ranges: []lxn.Range{{LowerBound: 11, UpperBound: 11}}},
+		},
+	},
+	{
+		Category: lxn.Few,
+		Rules: []lxn.PluralRule{
+			{Operand: lxn.NumFracDigits, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+			{Operand: lxn.IntegerDigits, Modulo: 10, Ranges: []lxn.Range{{LowerBound: 2, UpperBound: 4}}, Connective: lxn.Conjunction},
+			{Operand: lxn.IntegerDigits, Modulo: 100, Negate: true, Ranges: []lxn.Range{{LowerBound: 12, UpperBound: 14}}},
+		},
+	},
+	{
+		Category: lxn.Many,
+		Rules: []lxn.PluralRule{
+			{Operand: lxn.NumFracDigits, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+			{Operand: lxn.IntegerDigits, Modulo: 10, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Disjunction},
+			{Operand: lxn.NumFracDigits, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+			{Operand: lxn.IntegerDigits, Modulo: 10, Ranges: []lxn.Range{{LowerBound: 5, UpperBound: 9}}, Connective: lxn.Disjunction},
+			{Operand: lxn.NumFracDigits, Ranges: []lxn.Range{{LowerBound: 0, UpperBound: 0}}, Connective: lxn.Conjunction},
+			{Operand: lxn.IntegerDigits, Modulo: 100, Ranges: []lxn.Range{{LowerBound: 11, UpperBound: 14}}},
+		},
+	},
+	{
+		Category: lxn.Other,
+	},
+}
+
+func TestPluralRules(t *testing.T) {
+	expected := []PluralRule{
+		{Category: PluralOne, Condition: "v = 0 and i % 10 = 1 and i % 100 != 11"},
+		{Category: PluralFew, Condition: "v = 0 and i % 10 = 2..4 and i % 100 != 12..14"},
+		{Category: PluralMany, Condition: "v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14"},
+		{Category: PluralOther, Condition: ""},
+	}
+
+	loc := newLocale(lxn.Locale{CardinalPlurals: russianPlurals})
+	if rules := loc.CardinalRules(); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("unexpected plural rules: %+v", rules)
+	}
+	if rules := loc.OrdinalRules(); len(rules) != 0 {
+		t.Errorf("unexpected ordinal plural rules: %+v", rules)
+	}
+	if s := PluralFew.String(); s != "few" {
+		t.Errorf("unexpected category name: %s", s)
+	}
+}
+
+func TestLocalePluralCategory(t *testing.T) {
+	loc := newLocale(lxn.Locale{
+		DecimalFormat: lxn.NumberFormat{
+			Symbols:           lxn.Symbols{Zero: '0'},
+			MaxFractionDigits: 3,
+		},
+		CardinalPlurals: russianPlurals,
+		OrdinalPlurals: []lxn.Plural{
+			{
+				Category: lxn.Many,
+				Rules: []lxn.PluralRule{
+					{Operand: lxn.AbsoluteValue, Ranges: []lxn.Range{{LowerBound: 1, UpperBound: 1}}},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		value    Variable
+		ordinal  bool
+		expected PluralCategory
+	}{
+		{value: Int(1), expected: PluralOne},
+		{value: Int(21), expected: PluralOne},
+		{value: Int(11), expected: PluralMany},
+		{value: Uint(22), expected: PluralFew},
+		{value: Int(25), expected: PluralMany},
+		{value: Float(1.5), expected: PluralOther},
+		{value: String("1"), expected: PluralOther},
+		{value: Int(1), ordinal: true, expected: PluralMany},
+		{value: Int(2), ordinal: true, expected: PluralOther},
+	}
+
+	for _, test := range tests {
+		if got := loc.PluralCategory(test.value, test.ordinal); got != test.expected {
+			t.Errorf("unexpected category for %v (ordinal: %v): %v", test.value, test.ordinal, got)
+		}
+	}
+
+	if got := loc.PluralCategories(false); !reflect.DeepEqual(got, []PluralCategory{PluralOne, PluralFew, PluralMany, PluralOther}) {
+		t.Errorf("unexpected cardinal categories: %v", got)
+	}
+	if got := loc.PluralCategories(true); !reflect.DeepEqual(got, []PluralCategory{PluralMany, PluralOther}) {
+		t.Errorf("unexpected ordinal categories: %v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package lxn
+
+import (
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// roundNumber rounds the number 0.coeff × 10^intLen as defined by the number
+// format. If the format has a maximum number of significant digits, the number is
+// rounded to significant digits and the fraction digit settings are ignored.
+// Otherwise it is rounded to the maximum number of fraction digits. The function
+// returns the rounded coefficient, the number of integer digits, and the minimum
+// number of fraction digits to display.
+func roundNumber(coeff []byte, intLen int, neg bool, nf *lxn.NumberFormat) ([]byte, int, int) {
+	if !hasSignificantDigits(nf) {
+		coeff, intLen = roundDigits(coeff, intLen, nf.MaxFractionDigits, neg, nf.RoundingMode)
+		return coeff, intLen, nf.MinFractionDigits
+	}
+
+	if nf.MaxSignificantDigits > 0 && len(coeff) != 0 {
+		coeff, intLen = roundDigits(coeff, intLen, nf.MaxSignificantDigits-intLen, neg, nf.RoundingMode)
+	}
+
+	// A zero has one significant digit before the decimal point.
+	sigIntLen := intLen
+	if len(coeff) == 0 {
+		sigIntLen = 1
+	}
+	return coeff, intLen, max(nf.MinSignificantDigits-sigIntLen, 0)
+}
+
+// roundDigits rounds the number 0.coeff × 10^intLen to the given number of fraction
+// digits using the rounding mode. The coefficient digits ('0'-'9') must not have
+// leading zeros and are modified in place. The returned coefficient has no trailing
+// zeros and is empty if the number was rounded to zero.
+func roundDigits(coeff []byte, intLen int, fracDigits int, neg bool, mode lxn.RoundingMode) ([]byte, int) {
+	coeff = trimTrailingZeros(coeff)
+	keep := intLen + fracDigits // number of coefficient digits to keep
+	if len(coeff) == 0 || keep >= len(coeff) {
+		return coeff, intLen
+	}
+
+	// Since there are no trailing zeros in the coefficient, the dropped digits
+	// are never all zero.
+	var first byte // first dropped digit
+	if keep >= 0 {
+		first = coeff[keep] - '0'
+	}
+	more := keep+1 < len(coeff) // more non-zero digits after the first dropped one
+	odd := keep > 0 && (coeff[keep-1]-'0')%2 == 1
+
+	if !roundUp(mode, neg, first, more, odd) {
+		if keep <= 0 {
+			return coeff[:0], intLen
+		}
+		return trimTrailingZeros(coeff[:keep]), intLen
+	}
+
+	// Add one unit of the last kept digit, i.e. 10^-fracDigits. If the carry goes
+	// beyond the first digit, the result is a power of ten.
+	i := keep - 1
+	for i >= 0 && coeff[i] == '9' {
+		i--
+	}
+	if i < 0 {
+		coeff[0] = '1'
+		return coeff[:1], intLen - min(keep, 0) + 1
+	}
+	coeff[i]++
+	return coeff[:i+1], intLen
+}
+
+// roundUp reports whether the magnitude of a number has to be rounded up (away from
+// zero). The first dropped digit is given along with the information whether there
+// are more non-zero digits after it and whether the last kept digit is odd.
+func roundUp(mode lxn.RoundingMode, neg bool, first byte, more bool, odd bool) bool {
+	switch mode {
+	case lxn.RoundHalfUp:
+		return first >= 5
+	case lxn.RoundHalfDown:
+		return first > 5 || (first == 5 && more)
+	case lxn.RoundCeiling:
+		return !neg
+	case lxn.RoundFloor:
+		return neg
+	case lxn.RoundDown:
+		return false
+	case lxn.RoundUp:
+		return true
+	default: // lxn.RoundHalfEven
+		return first > 5 || (first == 5 && (more || odd))
+	}
+}
+
+func trimTrailingZeros(coeff []byte) []byte {
+	for len(coeff) > 0 && coeff[len(coeff)-1] == '0' {
+		coeff = coeff[:len(coeff)-1]
+	}
+	return coeff
+}
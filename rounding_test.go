@@ -0,0 +1,45 @@
+package lxn
+
+import (
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestRoundDigits(t *testing.T) {
+	modes := []lxn.RoundingMode{
+		lxn.RoundHalfEven,
+		lxn.RoundHalfUp,
+		lxn.RoundHalfDown,
+		lxn.RoundCeiling,
+		lxn.RoundFloor,
+		lxn.RoundDown,
+		lxn.RoundUp,
+	}
+
+	// expected results for each rounding mode in the order above
+	tests := []struct {
+		val      string
+		expected [7]string
+	}{
+		{val: "2.5", expected: [7]string{"2", "3", "2", "3", "2", "2", "3"}},
+		{val: "3.5", expected: [7]string{"4", "4", "3", "4", "3", "3", "4"}},
+		{val: "2.51", expected: [7]string{"3", "3", "3", "3", "2", "2", "3"}},
+		{val: "2.4", expected: [7]string{"2", "2", "2", "3", "2", "2", "3"}},
+		{val: "-2.5", expected: [7]string{"-2", "-3", "-2", "-2", "-3", "-2", "-3"}},
+		{val: "9.5", expected: [7]string{"10", "10", "9", "10", "9", "9", "10"}},
+		{val: "0.004", expected: [7]string{"0", "0", "0", "1", "0", "0", "1"}},
+		{val: "-0.5", expected: [7]string{"0", "-1", "0", "0", "-1", "0", "-1"}},
+		{val: "7", expected: [7]string{"7", "7", "7", "7", "7", "7", "7"}},
+	}
+
+	for _, test := range tests {
+		d := mustParseDecimal(test.val)
+		for i, mode := range modes {
+			coeff, intLen := roundDigits([]byte(d.coeff), d.intLen, 0, d.neg, mode)
+			if s := newDecimal(d.neg, coeff, intLen).String(); s != test.expected[i] {
+				t.Errorf("unexpected rounding of %s in mode %d: %s", test.val, mode, s)
+			}
+		}
+	}
+}
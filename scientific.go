@@ -0,0 +1,59 @@
+package lxn
+
+import (
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// formatScientific formats a number in scientific notation (e.g. "1.234E5"). The
+// step defines the multiple of the exponent: 1 for the scientific notation and 3
+// for the engineering notation (e.g. "123.4E3"). The mantissa is rounded to the
+// given number of significant digits.
+func formatScientific(w *writer, num number, nf *lxn.NumberFormat, step int, mantissaDigits int, superscript bool) {
+	d, ok := toDecimal(num)
+	if !ok {
+		num.format(w, nf, noCurrency)
+		return
+	}
+
+	if mantissaDigits <= 0 {
+		mantissaDigits = nf.MaxSignificantDigits
+	}
+	if mantissaDigits <= 0 {
+		mantissaDigits = nf.MaxFractionDigits + 1
+	}
+	d = d.round(mantissaDigits-d.intLen, nf.RoundingMode)
+
+	exponent := 0
+	if !d.IsZero() {
+		exponent = floorMultiple(d.intLen-1, step)
+	}
+	mantissa := d.shift(-exponent)
+
+	mnf := *nf
+	mnf.MinIntegerDigits = 1
+	mnf.MinFractionDigits = 0
+	mnf.MaxFractionDigits = max(len(mantissa.coeff)-mantissa.intLen, 0)
+	mnf.MinSignificantDigits = 0
+	mnf.MaxSignificantDigits = 0
+	mnf.PrimaryIntegerGrouping = 0
+	mnf.SecondaryIntegerGrouping = 0
+
+	var buf [maxFloatDigits]rune
+	intDigits, fracDigits := mantissa.digits(buf[:], &mnf, rune(nf.Symbols.Zero))
+	prefix, suffix := signAffixes(nf, d.neg, d.IsZero())
+
+	w.WriteAffix(prefix, &nf.Symbols, noCurrency)
+	w.WriteInt(intDigits, &mnf)
+	w.WriteFrac(fracDigits, &mnf)
+	w.WriteExponent(exponent, &nf.Symbols, superscript)
+	w.WriteAffix(suffix, &nf.Symbols, noCurrency)
+}
+
+// floorMultiple returns the largest multiple of m which is less than or equal to n.
+func floorMultiple(n int, m int) int {
+	r := n % m
+	if r < 0 {
+		r += m
+	}
+	return n - r
+}
@@ -0,0 +1,57 @@
+package lxn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestFormatScientific(t *testing.T) {
+	nf := lxn.NumberFormat{
+		Symbols: lxn.Symbols{
+			Zero:                   '0',
+			Decimal:                ".",
+			Group:                  ",",
+			Minus:                  "-",
+			Inf:                    "∞",
+			Exponential:            "E",
+			SuperscriptingExponent: "×",
+		},
+		NegativePrefix:           "-",
+		MaxFractionDigits:        3,
+		PrimaryIntegerGrouping:   3,
+		SecondaryIntegerGrouping: 3,
+	}
+
+	tests := []struct {
+		num            number
+		step           int
+		mantissaDigits int
+		superscript    bool
+		expected       string
+	}{
+		{num: Int(0), step: 1, expected: "0E0"},
+		{num: Int(1), step: 1, expected: "1E0"},
+		{num: Int(123456), step: 1, expected: "1.235E5"},
+		{num: Int(-123456), step: 1, expected: "-1.235E5"},
+		{num: Float(0.00012), step: 1, expected: "1.2E-4"},
+		{num: Float(9.99996), step: 1, expected: "1E1"},
+		{num: Int(123456), step: 1, mantissaDigits: 2, expected: "1.2E5"},
+		{num: Float(6.02214076e23), step: 1, superscript: true, expected: "6.022×10²³"},
+		{num: Float(1.5e-12), step: 1, superscript: true, expected: "1.5×10⁻¹²"},
+		{num: Int(123456), step: 3, expected: "123.5E3"},
+		{num: Int(1234567), step: 3, expected: "1.235E6"},
+		{num: Float(0.00012), step: 3, expected: "120E-6"},
+		{num: mustParseDecimal("12345678901234567890123"), step: 3, mantissaDigits: 5, expected: "12.346E21"},
+		{num: Float(math.Inf(1)), step: 1, expected: "∞"},
+	}
+
+	for _, test := range tests {
+		var w writer
+		formatScientific(&w, test.num, &nf, test.step, test.mantissaDigits, test.superscript)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected scientific format for %q: %s", test.expected, s)
+		}
+	}
+}
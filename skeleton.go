@@ -0,0 +1,197 @@
+package lxn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+// unlimitedDigits is the number of fraction or significant digits used for
+// unlimited precision.
+const unlimitedDigits = 1 << 20
+
+var skeletonRoundingModes = map[string]lxn.RoundingMode{
+	"rounding-mode-half-even": lxn.RoundHalfEven,
+	"rounding-mode-half-up":   lxn.RoundHalfUp,
+	"rounding-mode-half-down": lxn.RoundHalfDown,
+	"rounding-mode-ceiling":   lxn.RoundCeiling,
+	"rounding-mode-floor":     lxn.RoundFloor,
+	"rounding-mode-down":      lxn.RoundDown,
+	"rounding-mode-up":        lxn.RoundUp,
+}
+
+// skeleton is a parsed ICU number skeleton. Each option overrides a part of
+// the number format.
+type skeleton []func(nf *lxn.NumberFormat)
+
+// skeletonFormat returns the number format with the options of the skeleton
+// applied. Without a skeleton, the number format is returned as is.
+func skeletonFormat(nf *lxn.NumberFormat, sk skeleton) *lxn.NumberFormat {
+	if len(sk) == 0 {
+		return nf
+	}
+	snf := *nf
+	sk.apply(&snf)
+	return &snf
+}
+
+// apply applies the options of the skeleton to the number format.
+func (sk skeleton) apply(nf *lxn.NumberFormat) {
+	for _, opt := range sk {
+		opt(nf)
+	}
+}
+
+// applySkeleton parses an ICU number skeleton and applies its options to the
+// number format.
+func applySkeleton(nf *lxn.NumberFormat, s string) error {
+	sk, err := parseSkeleton(s)
+	if err != nil {
+		return err
+	}
+	sk.apply(nf)
+	return nil
+}
+
+// parseSkeleton parses an ICU number skeleton. The skeleton consists of
+// space-separated tokens. The following tokens are supported:
+//
+//	.00, .0#, .00+, .         fraction digits (precision-integer for ".")
+//	@@@, @@#, @@+             significant digits
+//	precision-integer         no fraction digits
+//	precision-unlimited       all fraction digits
+//	group-off, ,_             no grouping
+//	group-auto                locale grouping
+//	group-min2, ,?            grouping for at least two digits in the leading group
+//	group-on-aligned, ,!      grouping regardless of the minimum grouping digits
+//	integer-width/+000        minimum number of integer digits
+//	sign-auto                 minus sign for negative numbers only
+//	sign-always, +!           plus sign for positive numbers
+//	sign-never, +_            no sign at all
+//	sign-except-zero, +?      plus sign for positive numbers, no sign for zero
+//	rounding-mode-*           rounding mode (half-even, half-up, ...)
+//
+// https://unicode-org.github.io/icu/userguide/format_parse/numbers/skeletons.html
+func parseSkeleton(s string) (skeleton, error) {
+	tokens := strings.Fields(s)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	sk := make(skeleton, 0, len(tokens))
+	for _, token := range tokens {
+		switch {
+		case token == "precision-integer" || token == ".":
+			sk = append(sk, fractionDigits(0, 0))
+
+		case token == "precision-unlimited":
+			sk = append(sk, fractionDigits(0, unlimitedDigits))
+
+		case token[0] == '.':
+			minDigits, maxDigits, ok := skeletonDigits(token[1:], '0')
+			if !ok {
+				return nil, fmt.Errorf("invalid fraction precision %q in number skeleton", token)
+			}
+			sk = append(sk, fractionDigits(minDigits, maxDigits))
+
+		case token[0] == '@':
+			minDigits, maxDigits, ok := skeletonDigits(token, '@')
+			if !ok || minDigits == 0 {
+				return nil, fmt.Errorf("invalid significant precision %q in number skeleton", token)
+			}
+			sk = append(sk, func(nf *lxn.NumberFormat) {
+				nf.MinSignificantDigits, nf.MaxSignificantDigits = minDigits, maxDigits
+			})
+
+		case token == "group-off" || token == ",_":
+			sk = append(sk, func(nf *lxn.NumberFormat) {
+				nf.PrimaryIntegerGrouping, nf.SecondaryIntegerGrouping = 0, 0
+			})
+
+		case token == "group-auto":
+			// keep the locale's grouping
+
+		case token == "group-min2" || token == ",?":
+			sk = append(sk, minGroupingDigits(2))
+
+		case token == "group-on-aligned" || token == ",!":
+			sk = append(sk, minGroupingDigits(1))
+
+		case strings.HasPrefix(token, "integer-width/"):
+			width := strings.TrimPrefix(token, "integer-width/")
+			if width == "" || (width[0] != '+' && width[0] != '*') || strings.Trim(width[1:], "0") != "" {
+				return nil, fmt.Errorf("invalid integer width %q in number skeleton", token)
+			}
+			if len(width)-1 > maxPrecision {
+				return nil, fmt.Errorf("integer width in number skeleton exceeds %d digits", maxPrecision)
+			}
+			sk = append(sk, func(nf *lxn.NumberFormat) {
+				nf.MinIntegerDigits = len(width) - 1
+			})
+
+		case token == "sign-auto":
+			sk = append(sk, signDisplay(lxn.SignAuto))
+
+		case token == "sign-always" || token == "+!":
+			sk = append(sk, signDisplay(lxn.SignAlways))
+
+		case token == "sign-never" || token == "+_":
+			sk = append(sk, signDisplay(lxn.SignNever))
+
+		case token == "sign-except-zero" || token == "+?":
+			sk = append(sk, signDisplay(lxn.SignExceptZero))
+
+		default:
+			mode, has := skeletonRoundingModes[token]
+			if !has {
+				return nil, fmt.Errorf("unsupported token %q in number skeleton", token)
+			}
+			sk = append(sk, func(nf *lxn.NumberFormat) {
+				nf.RoundingMode = mode
+			})
+		}
+	}
+	return sk, nil
+}
+
+func fractionDigits(minDigits, maxDigits int) func(*lxn.NumberFormat) {
+	return func(nf *lxn.NumberFormat) {
+		nf.MinFractionDigits, nf.MaxFractionDigits = minDigits, maxDigits
+		nf.MinSignificantDigits, nf.MaxSignificantDigits = 0, 0
+	}
+}
+
+func minGroupingDigits(n int) func(*lxn.NumberFormat) {
+	return func(nf *lxn.NumberFormat) {
+		nf.MinGroupingDigits = n
+	}
+}
+
+func signDisplay(sign lxn.SignDisplay) func(*lxn.NumberFormat) {
+	return func(nf *lxn.NumberFormat) {
+		nf.SignDisplay = sign
+	}
+}
+
+// skeletonDigits parses a precision stem like "00#", "00+", or "@@#". The
+// required digits are denoted by the given character, optional digits by '#'.
+// A trailing '+' allows an unlimited number of digits. Stems with more than
+// maxPrecision digits are invalid.
+func skeletonDigits(stem string, required byte) (minDigits int, maxDigits int, ok bool) {
+	if len(strings.TrimSuffix(stem, "+")) > maxPrecision {
+		return 0, 0, false
+	}
+	for minDigits < len(stem) && stem[minDigits] == required {
+		minDigits++
+	}
+	rest := stem[minDigits:]
+	switch {
+	case rest == "+":
+		return minDigits, unlimitedDigits, true
+	case strings.Trim(rest, "#") == "":
+		return minDigits, len(stem), true
+	default:
+		return 0, 0, false
+	}
+}
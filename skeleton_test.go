@@ -0,0 +1,118 @@
+package lxn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liblxn/lxn-go/internal/lxn"
+)
+
+func TestApplySkeleton(t *testing.T) {
+	base := lxn.NumberFormat{
+		Symbols: lxn.Symbols{
+			Zero:    '0',
+			Decimal: ".",
+			Group:   ",",
+			Minus:   "-",
+			Plus:    "+",
+		},
+		NegativePrefix:           "-",
+		MaxFractionDigits:        3,
+		PrimaryIntegerGrouping:   3,
+		SecondaryIntegerGrouping: 3,
+	}
+
+	tests := []struct {
+		skeleton string
+		num      number
+		expected string
+	}{
+		{skeleton: "", num: Float(1234.5678), expected: "1,234.568"},
+		{skeleton: ".00", num: Float(1234.5), expected: "1,234.50"},
+		{skeleton: ".0#", num: Float(1234.567), expected: "1,234.57"},
+		{skeleton: ".0#", num: Int(12), expected: "12.0"},
+		{skeleton: ".00+", num: Float(1.23456789), expected: "1.23456789"},
+		{skeleton: ".", num: Float(1234.5678), expected: "1,235"},
+		{skeleton: "precision-integer", num: Float(2.5), expected: "2"},
+		{skeleton: "precision-unlimited", num: mustParseDecimal("0.123456789"), expected: "0.123456789"},
+		{skeleton: "@@@", num: Float(0.001234), expected: "0.00123"},
+		{skeleton: "@@#", num: Int(1), expected: "1.0"},
+		{skeleton: "@@+", num: Float(1.2345), expected: "1.2345"},
+		{skeleton: "group-off", num: Int(1234567), expected: "1234567"},
+		{skeleton: ",_", num: Int(1234567), expected: "1234567"},
+		{skeleton: "group-auto", num: Int(1234567), expected: "1,234,567"},
+		{skeleton: "group-min2", num: Int(1234), expected: "1234"},
+		{skeleton: ",? ,!", num: Int(1234), expected: "1,234"},
+		{skeleton: "integer-width/*0000", num: Int(12), expected: "0,012"},
+		{skeleton: "sign-always", num: Int(12), expected: "+12"},
+		{skeleton: "+!", num: Int(-12), expected: "-12"},
+		{skeleton: "sign-never", num: Int(-12), expected: "12"},
+		{skeleton: "sign-auto", num: Int(-12), expected: "-12"},
+		{skeleton: "sign-except-zero", num: Float(0.0001), expected: "0"},
+		{skeleton: "+?", num: Float(-0.5), expected: "-0.5"},
+		{skeleton: ". rounding-mode-ceiling", num: Float(1.1), expected: "2"},
+		{skeleton: ".00 group-off +_", num: Float(-1234.5), expected: "1234.50"},
+	}
+
+	for _, test := range tests {
+		nf := base
+		if err := applySkeleton(&nf, test.skeleton); err != nil {
+			t.Errorf("unexpected error for %q: %v", test.skeleton, err)
+			continue
+		}
+
+		var w writer
+		test.num.format(&w, &nf, noCurrency)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected format for skeleton %q: %s", test.skeleton, s)
+		}
+	}
+
+	invalid := []string{
+		".0a", ".#0", "@#@", "#", "integer-width/", "integer-width/000", "integer-width/+0#", "foo",
+		"." + strings.Repeat("0", maxPrecision+1),
+		"." + strings.Repeat("#", maxPrecision+1),
+		strings.Repeat("@", maxPrecision+1),
+		"integer-width/+" + strings.Repeat("0", maxPrecision+1),
+	}
+	for _, skeleton := range invalid {
+		nf := base
+		if err := applySkeleton(&nf, skeleton); err == nil {
+			t.Errorf("expected error for %q", skeleton)
+		}
+	}
+}
+
+func TestSkeletonPrecisionLimit(t *testing.T) {
+	base := lxn.NumberFormat{
+		Symbols: lxn.Symbols{Zero: '0', Decimal: ".", Minus: "-"},
+	}
+
+	tests := []struct {
+		skeleton string
+		intLen   int
+		fracLen  int
+	}{
+		{skeleton: "." + strings.Repeat("0", 65), intLen: 1, fracLen: 65},
+		{skeleton: "." + strings.Repeat("0", maxPrecision), intLen: 1, fracLen: maxPrecision},
+		{skeleton: "integer-width/+" + strings.Repeat("0", 65), intLen: 65},
+		{skeleton: "integer-width/+" + strings.Repeat("0", maxPrecision), intLen: maxPrecision},
+	}
+
+	for _, test := range tests {
+		nf := base
+		if err := applySkeleton(&nf, test.skeleton); err != nil {
+			t.Errorf("unexpected error for %d/%d digits: %v", test.intLen, test.fracLen, err)
+			continue
+		}
+
+		for _, num := range []number{Int(-5), Uint(5), Float(5), mustParseDecimal("5")} {
+			var w writer
+			num.format(&w, &nf, noCurrency)
+			intPart, fracPart, _ := strings.Cut(strings.TrimPrefix(w.String(), "-"), ".")
+			if len(intPart) != test.intLen || len(fracPart) != test.fracLen {
+				t.Errorf("unexpected format of %v for %d/%d digits: %d/%d", num, test.intLen, test.fracLen, len(intPart), len(fracPart))
+			}
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package lxn
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Tag is a BCP 47 language tag, e.g. "en-US" or "ar-EG-u-nu-latn". A tag is
+// always kept in its canonical form, so two tags can be compared with ==.
+type Tag struct {
+	lang     string // lowercase, "und" for the root locale
+	script   string // title case
+	region   string // uppercase letters or three digits
+	variants string // lowercase and separated by '-'
+	exts     string // lowercase extensions and private use, e.g. "u-nu-latn"
+}
+
+// ParseTag parses a BCP 47 language tag. The tag is canonicalized, i.e. the case
+// of all subtags is normalized, underscores are replaced by hyphens, and the
+// extensions are sorted, e.g. "en_us" becomes "en-US". The tag "root" denotes
+// the root locale "und".
+func ParseTag(s string) (Tag, error) {
+	if s == "" {
+		return Tag{}, fmt.Errorf("empty language tag")
+	}
+
+	subtags := strings.Split(strings.ToLower(strings.ReplaceAll(s, "_", "-")), "-")
+	for _, subtag := range subtags {
+		if subtag == "" || len(subtag) > 8 || !isAlnum(subtag) {
+			return Tag{}, fmt.Errorf("invalid subtag %q in language tag %q", subtag, s)
+		}
+	}
+
+	var t Tag
+	switch lang := subtags[0]; {
+	case lang == "root":
+		t.lang = "und"
+	case isAlpha(lang) && len(lang) != 4 && len(lang) >= 2:
+		t.lang = lang
+	default:
+		return Tag{}, fmt.Errorf("invalid language %q in language tag %q", lang, s)
+	}
+	subtags = subtags[1:]
+
+	if len(subtags) > 0 && len(subtags[0]) == 4 && isAlpha(subtags[0]) {
+		t.script = strings.ToUpper(subtags[0][:1]) + subtags[0][1:]
+		subtags = subtags[1:]
+	}
+	if len(subtags) > 0 && ((len(subtags[0]) == 2 && isAlpha(subtags[0])) || (len(subtags[0]) == 3 && isDigits(subtags[0]))) {
+		t.region = strings.ToUpper(subtags[0])
+		subtags = subtags[1:]
+	}
+
+	var variants []string
+	for len(subtags) > 0 && isVariant(subtags[0]) {
+		if slices.Contains(variants, subtags[0]) {
+			return Tag{}, fmt.Errorf("duplicate variant %q in language tag %q", subtags[0], s)
+		}
+		variants = append(variants, subtags[0])
+		subtags = subtags[1:]
+	}
+	t.variants = strings.Join(variants, "-")
+
+	exts, err := parseExtensions(subtags)
+	if err != nil {
+		return Tag{}, fmt.Errorf("%v in language tag %q", err, s)
+	}
+	t.exts = exts
+	return t, nil
+}
+
+// parseExtensions parses the extension and private use subtags of a language tag
+// and returns them in canonical order.
+func parseExtensions(subtags []string) (string, error) {
+	var exts [][]string // singleton followed by its subtags
+	for len(subtags) > 0 {
+		singleton := subtags[0]
+		if len(singleton) != 1 {
+			return "", fmt.Errorf("invalid subtag %q", singleton)
+		}
+
+		n := 1
+		if singleton == "x" {
+			n = len(subtags) // private use consumes all remaining subtags
+		} else {
+			for n < len(subtags) && len(subtags[n]) > 1 {
+				n++
+			}
+		}
+		if n == 1 {
+			return "", fmt.Errorf("empty extension %q", singleton)
+		}
+		if slices.ContainsFunc(exts, func(ext []string) bool { return ext[0] == singleton }) {
+			return "", fmt.Errorf("duplicate extension %q", singleton)
+		}
+
+		ext := slices.Clone(subtags[:n])
+		if singleton == "u" {
+			ext = append(ext[:1], canonicalUnicodeExtension(ext[1:])...)
+		}
+		exts = append(exts, ext)
+		subtags = subtags[n:]
+	}
+
+	// The extensions are sorted by their singleton. The private use always comes
+	// last, since it consumes all remaining subtags.
+	slices.SortStableFunc(exts, func(x, y []string) int {
+		switch {
+		case x[0] == "x":
+			return 1
+		case y[0] == "x":
+			return -1
+		default:
+			return strings.Compare(x[0], y[0])
+		}
+	})
+
+	parts := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		parts = append(parts, strings.Join(ext, "-"))
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// canonicalUnicodeExtension sorts the keywords of a Unicode locale extension by
+// their keys. Duplicate keys are dropped, only the first one is kept.
+func canonicalUnicodeExtension(subtags []string) []string {
+	n := 0
+	for n < len(subtags) && len(subtags[n]) != 2 {
+		n++ // attributes
+	}
+	attrs := subtags[:n]
+
+	var keywords [][]string
+	for n < len(subtags) {
+		end := n + 1
+		for end < len(subtags) && len(subtags[end]) != 2 {
+			end++
+		}
+		key := subtags[n]
+		if !slices.ContainsFunc(keywords, func(kw []string) bool { return kw[0] == key }) {
+			keywords = append(keywords, subtags[n:end])
+		}
+		n = end
+	}
+	slices.SortStableFunc(keywords, func(x, y []string) int {
+		return strings.Compare(x[0], y[0])
+	})
+
+	res := make([]string, 0, len(subtags))
+	res = append(res, attrs...)
+	for _, kw := range keywords {
+		res = append(res, kw...)
+	}
+	return res
+}
+
+// MustParseTag is like ParseTag but panics if the tag cannot be parsed.
+func MustParseTag(s string) Tag {
+	t, err := ParseTag(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// String returns the canonical form of the tag.
+func (t Tag) String() string {
+	if t.lang == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(t.lang)
+	for _, subtag := range [...]string{t.script, t.region, t.variants, t.exts} {
+		if subtag != "" {
+			sb.WriteByte('-')
+			sb.WriteString(subtag)
+		}
+	}
+	return sb.String()
+}
+
+// IsRoot reports whether the tag denotes the root locale.
+func (t Tag) IsRoot() bool {
+	return t == Tag{lang: "und"}
+}
+
+// Language returns the language subtag, e.g. "en".
+func (t Tag) Language() string {
+	return t.lang
+}
+
+// Script returns the script subtag, e.g. "Latn", or an empty string.
+func (t Tag) Script() string {
+	return t.script
+}
+
+// Region returns the region subtag, e.g. "US" or "419", or an empty string.
+func (t Tag) Region() string {
+	return t.region
+}
+
+// Variants returns the variant subtags.
+func (t Tag) Variants() []string {
+	if t.variants == "" {
+		return nil
+	}
+	return strings.Split(t.variants, "-")
+}
+
+// Unicode returns the value of a keyword of the Unicode locale extension, e.g.
+// "latn" for the key "nu" in "ar-u-nu-latn". If the keyword does not exist, an
+// empty string will be returned.
+func (t Tag) Unicode(key string) string {
+	subtags := t.unicodeExtension()
+	for i := 0; i < len(subtags); i++ {
+		if subtags[i] != key {
+			continue
+		}
+		end := i + 1
+		for end < len(subtags) && len(subtags[end]) != 2 {
+			end++
+		}
+		return strings.Join(subtags[i+1:end], "-")
+	}
+	return ""
+}
+
+// unicodeExtension returns the subtags of the Unicode locale extension without
+// the singleton.
+func (t Tag) unicodeExtension() []string {
+	subtags := strings.Split(t.exts, "-")
+	for i := 0; i < len(subtags); i++ {
+		switch {
+		case subtags[i] == "x":
+			return nil
+		case subtags[i] == "u":
+			end := i + 1
+			for end < len(subtags) && len(subtags[end]) != 1 {
+				end++
+			}
+			return subtags[i+1 : end]
+		}
+	}
+	return nil
+}
+
+// withUnicode returns the tag with the keyword of the Unicode extension set to
+// the given value.
+func (t Tag) withUnicode(key string, value string) Tag {
+	subtags := strings.Split(t.exts, "-")
+	if t.exts == "" {
+		subtags = nil
+	}
+
+	// The keyword is inserted after the attributes as the first keyword, since
+	// the canonicalization keeps the first occurrence of a key only.
+	idx := slices.Index(subtags, "u")
+	if xidx := slices.Index(subtags, "x"); idx < 0 || (xidx >= 0 && xidx < idx) {
+		subtags = slices.Insert(subtags, 0, "u")
+		idx = 0
+	}
+	idx++
+	for idx < len(subtags) && len(subtags[idx]) > 2 {
+		idx++ // attributes
+	}
+	subtags = slices.Insert(subtags, idx, key, value)
+
+	res, err := ParseTag(t.Base().String() + "-" + strings.Join(subtags, "-"))
+	if err != nil {
+		return t
+	}
+	return res
+}
+
+// Base returns the tag without extensions and private use, e.g. "ar-EG" for
+// "ar-EG-u-nu-latn".
+func (t Tag) Base() Tag {
+	t.exts = ""
+	return t
+}
+
+// Parent returns the parent locale according to the CLDR inheritance. The tag is
+// stripped of its extensions first, afterwards the explicit CLDR parents are
+// considered (e.g. "es-419" for "es-AR") before the last subtag is truncated. The
+// parent of a language is the root locale, which itself has no parent.
+func (t Tag) Parent() (Tag, bool) {
+	if t.exts != "" {
+		return t.Base(), true
+	}
+	if parent, has := parentLocales[t.String()]; has {
+		return MustParseTag(parent), true
+	}
+
+	switch {
+	case t.variants != "":
+		if idx := strings.LastIndexByte(t.variants, '-'); idx >= 0 {
+			t.variants = t.variants[:idx]
+		} else {
+			t.variants = ""
+		}
+	case t.region != "":
+		t.region = ""
+	case t.script != "":
+		t.script = ""
+	case t.lang != "" && !t.IsRoot():
+		return Tag{lang: "und"}, true
+	default:
+		return Tag{}, false
+	}
+	return t, true
+}
+
+// sameLocale reports whether both locale ids refer to the same locale. The ids
+// are compared by their canonical tags without extensions, since extensions do
+// not affect the messages. Ids which are no valid tags have to match exactly.
+func sameLocale(id1, id2 string) bool {
+	if id1 == id2 {
+		return true
+	}
+	t1, err1 := ParseTag(id1)
+	t2, err2 := ParseTag(id2)
+	return err1 == nil && err2 == nil && t1.Base() == t2.Base()
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'a' || s[i] > 'z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isVariant reports whether the subtag is a variant, i.e. it has five to eight
+// characters or four characters starting with a digit.
+func isVariant(s string) bool {
+	return len(s) >= 5 || (len(s) == 4 && '0' <= s[0] && s[0] <= '9')
+}
+
+// parentLocales holds the explicit parent locales of CLDR, which deviate from
+// the truncation inheritance.
+//
+// https://github.com/unicode-org/cldr/blob/main/common/supplemental/supplementalData.xml
+var parentLocales = map[string]string{}
+
+func init() {
+	parents := map[string][]string{
+		"und": {
+			"az-Arab", "az-Cyrl", "bal-Latn", "blt-Latn", "bm-Nkoo", "bs-Cyrl", "byn-Latn", "cu-Glag",
+			"dje-Arab", "dyo-Arab", "en-Dsrt", "en-Shaw", "ff-Adlm", "ff-Arab", "ha-Arab", "iu-Latn",
+			"kk-Arab", "ks-Deva", "ku-Arab", "ky-Arab", "ky-Latn", "ml-Arab", "mn-Mong", "mni-Mtei",
+			"ms-Arab", "pa-Arab", "sat-Deva", "sd-Deva", "sd-Khoj", "sd-Sind", "shi-Latn", "so-Arab",
+			"sr-Latn", "sw-Arab", "tg-Arab", "ug-Cyrl", "uz-Arab", "uz-Cyrl", "vai-Latn", "wo-Arab",
+			"yo-Arab", "yue-Hans", "zh-Hant",
+		},
+		"en-001": {
+			"en-150", "en-AG", "en-AI", "en-AU", "en-BB", "en-BM", "en-BS", "en-BW", "en-BZ", "en-CC",
+			"en-CK", "en-CM", "en-CX", "en-CY", "en-DG", "en-DM", "en-ER", "en-FJ", "en-FK", "en-FM",
+			"en-GB", "en-GD", "en-GG", "en-GH", "en-GI", "en-GM", "en-GY", "en-HK", "en-ID", "en-IE",
+			"en-IL", "en-IM", "en-IN", "en-IO", "en-JE", "en-JM", "en-KE", "en-KI", "en-KN", "en-KY",
+			"en-LC", "en-LR", "en-LS", "en-MG", "en-MO", "en-MS", "en-MT", "en-MU", "en-MV", "en-MW",
+			"en-MY", "en-NA", "en-NF", "en-NG", "en-NR", "en-NU", "en-NZ", "en-PG", "en-PK", "en-PN",
+			"en-PW", "en-RW", "en-SB", "en-SC", "en-SD", "en-SG", "en-SH", "en-SL", "en-SS", "en-SX",
+			"en-SZ", "en-TC", "en-TK", "en-TO", "en-TT", "en-TV", "en-TZ", "en-UG", "en-VC", "en-VG",
+			"en-VU", "en-WS", "en-ZA", "en-ZM", "en-ZW",
+		},
+		"en-150": {
+			"en-AT", "en-BE", "en-CH", "en-DE", "en-DK", "en-FI", "en-NL", "en-SE", "en-SI",
+		},
+		"es-419": {
+			"es-AR", "es-BO", "es-BR", "es-BZ", "es-CL", "es-CO", "es-CR", "es-CU", "es-DO", "es-EC",
+			"es-GT", "es-HN", "es-MX", "es-NI", "es-PA", "es-PE", "es-PR", "es-PY", "es-SV", "es-US",
+			"es-UY", "es-VE",
+		},
+		"pt-PT": {
+			"pt-AO", "pt-CH", "pt-CV", "pt-FR", "pt-GQ", "pt-GW", "pt-LU", "pt-MO", "pt-MZ", "pt-ST",
+			"pt-TL",
+		},
+		"zh-Hant-HK": {
+			"zh-Hant-MO",
+		},
+	}
+
+	for parent, children := range parents {
+		for _, child := range children {
+			parentLocales[child] = parent
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package lxn
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+		script   string
+		region   string
+		variants []string
+	}{
+		{tag: "en", expected: "en"},
+		{tag: "en_us", expected: "en-US", region: "US"},
+		{tag: "EN-us", expected: "en-US", region: "US"},
+		{tag: "root", expected: "und"},
+		{tag: "zh-hant-tw", expected: "zh-Hant-TW", script: "Hant", region: "TW"},
+		{tag: "es-419", expected: "es-419", region: "419"},
+		{tag: "sl-rozaj-biske", expected: "sl-rozaj-biske", variants: []string{"rozaj", "biske"}},
+		{tag: "de-CH-1901", expected: "de-CH-1901", region: "CH", variants: []string{"1901"}},
+		{tag: "ar-EG-u-nu-latn", expected: "ar-EG-u-nu-latn", region: "EG"},
+		{tag: "en-u-nu-thai-ca-buddhist", expected: "en-u-ca-buddhist-nu-thai"},
+		{tag: "en-u-nu-thai-nu-arab", expected: "en-u-nu-thai"},
+		{tag: "en-x-foo-u-nu-arab-t-ja", expected: "en-x-foo-u-nu-arab-t-ja"},
+		{tag: "en-x-foo-b-bar-a-baz", expected: "en-x-foo-b-bar-a-baz"},
+		{tag: "en-b-bar-a-baz-x-foo", expected: "en-a-baz-b-bar-x-foo"},
+	}
+
+	for _, test := range tests {
+		tag, err := ParseTag(test.tag)
+		switch {
+		case err != nil:
+			t.Errorf("unexpected error for %q: %v", test.tag, err)
+		case tag.String() != test.expected:
+			t.Errorf("unexpected tag for %q: %q", test.tag, tag.String())
+		case tag.Script() != test.script:
+			t.Errorf("unexpected script for %q: %q", test.tag, tag.Script())
+		case tag.Region() != test.region:
+			t.Errorf("unexpected region for %q: %q", test.tag, tag.Region())
+		case !reflect.DeepEqual(tag.Variants(), test.variants):
+			t.Errorf("unexpected variants for %q: %v", test.tag, tag.Variants())
+		}
+	}
+}
+
+func TestParseTagWithInvalidInput(t *testing.T) {
+	tags := []string{
+		"",
+		"e",
+		"engl",
+		"en--US",
+		"en-US-",
+		"en-toolongsubtag",
+		"en-US-rozaj-rozaj",
+		"en-u",
+		"en-u-nu-latn-u-ca-gregory",
+		"en-US-ab",
+		"en-ä",
+	}
+
+	for _, tag := range tags {
+		if _, err := ParseTag(tag); err == nil {
+			t.Errorf("expected error for %q", tag)
+		}
+	}
+}
+
+func TestTagUnicode(t *testing.T) {
+	tag := MustParseTag("ar-EG-u-attr-nu-latn-ca-islamic-civil-x-u-nu-arab")
+
+	tests := map[string]string{
+		"nu": "latn",
+		"ca": "islamic-civil",
+		"rg": "",
+	}
+	for key, expected := range tests {
+		if got := tag.Unicode(key); got != expected {
+			t.Errorf("unexpected value for %s: %q", key, got)
+		}
+	}
+
+	if base := tag.Base(); base != MustParseTag("ar-EG") {
+		t.Errorf("unexpected base tag: %s", base)
+	}
+}
+
+func TestTagParent(t *testing.T) {
+	tests := map[string][]string{
+		"es-AR":              {"es-419", "es", "und"},
+		"en-AT":              {"en-150", "en-001", "en", "und"},
+		"pt-MZ":              {"pt-PT", "pt", "und"},
+		"zh-Hant-MO":         {"zh-Hant-HK", "zh-Hant", "und"},
+		"sr-Latn-RS":         {"sr-Latn", "und"},
+		"de-CH-1901-fonipa":  {"de-CH-1901", "de-CH", "de", "und"},
+		"ar-EG-u-nu-latn":    {"ar-EG", "ar", "und"},
+		"und":                {},
+		"es-419-u-rg-mxzzzz": {"es-419", "es", "und"},
+	}
+
+	for tag, expected := range tests {
+		parents := []string{}
+		for t, has := MustParseTag(tag).Parent(); has; t, has = t.Parent() {
+			parents = append(parents, t.String())
+		}
+		if !reflect.DeepEqual(parents, expected) {
+			t.Errorf("unexpected parents for %s: %v", tag, parents)
+		}
+	}
+}
+
+func TestSameLocale(t *testing.T) {
+	tests := []struct {
+		id1, id2 string
+		expected bool
+	}{
+		{id1: "en-US", id2: "en-US", expected: true},
+		{id1: "en_us", id2: "en-US", expected: true},
+		{id1: "ar-EG", id2: "ar-eg-u-nu-latn", expected: true},
+		{id1: "en-US", id2: "en-GB", expected: false},
+		{id1: "en", id2: "en-US", expected: false},
+		{id1: "invalid--id", id2: "invalid--id", expected: true},
+		{id1: "invalid--id", id2: "INVALID--ID", expected: false},
+	}
+
+	for _, test := range tests {
+		if got := sameLocale(test.id1, test.id2); got != test.expected {
+			t.Errorf("unexpected result for %s and %s: %v", test.id1, test.id2, got)
+		}
+	}
+}
+
+func TestTagWithUnicode(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+	}{
+		{tag: "ar-EG", expected: "ar-EG-u-nu-latn"},
+		{tag: "ar-EG-u-nu-arab", expected: "ar-EG-u-nu-latn"},
+		{tag: "ar-EG-u-ca-islamic", expected: "ar-EG-u-ca-islamic-nu-latn"},
+		{tag: "ar-EG-u-attr-ca-islamic", expected: "ar-EG-u-attr-ca-islamic-nu-latn"},
+		{tag: "ar-EG-t-ja-x-u-nu-arab", expected: "ar-EG-t-ja-u-nu-latn-x-u-nu-arab"},
+	}
+
+	for _, test := range tests {
+		if got := MustParseTag(test.tag).withUnicode("nu", "latn").String(); got != test.expected {
+			t.Errorf("unexpected tag for %s: %s", test.tag, got)
+		}
+	}
+}
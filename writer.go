@@ -1,9 +1,10 @@
 package lxn
 
 import (
+	"slices"
 	"strconv"
-	"strings"
 	"unicode/utf8"
+	"unsafe"
 
 	"github.com/liblxn/lxn-go/internal/lxn"
 )
@@ -11,11 +12,45 @@ import (
 const (
 	currencyPlaceholder = '¤'
 	minusPlaceholder    = '-'
+	plusPlaceholder     = '+'
 	percentPlaceholder  = '%'
+	perMillePlaceholder = '‰'
 )
 
+const superscriptMinus = '⁻'
+
+var superscriptDigits = [10]rune{'⁰', '¹', '²', '³', '⁴', '⁵', '⁶', '⁷', '⁸', '⁹'}
+
+// writer collects the formatted output. It appends to its buffer, which can be
+// preset with a caller's buffer to format into it directly.
 type writer struct {
-	strings.Builder
+	buf []byte
+}
+
+// String returns the written output. Like strings.Builder, it does not copy the
+// buffer, which is safe since the writer only ever appends to it.
+func (w *writer) String() string {
+	return unsafe.String(unsafe.SliceData(w.buf), len(w.buf))
+}
+
+func (w *writer) Grow(n int) {
+	w.buf = slices.Grow(w.buf, n)
+}
+
+func (w *writer) WriteString(s string) (int, error) {
+	w.buf = append(w.buf, s...)
+	return len(s), nil
+}
+
+func (w *writer) WriteByte(b byte) error {
+	w.buf = append(w.buf, b)
+	return nil
+}
+
+func (w *writer) WriteRune(r rune) (int, error) {
+	n := len(w.buf)
+	w.buf = utf8.AppendRune(w.buf, r)
+	return len(w.buf) - n, nil
 }
 
 func (w *writer) WriteRunes(runes []rune) {
@@ -32,8 +67,12 @@ func (w *writer) WriteAffix(affix string, symb *lxn.Symbols, currency string) {
 			w.WriteString(currency)
 		case minusPlaceholder:
 			w.WriteString(symb.Minus)
+		case plusPlaceholder:
+			w.WriteString(symb.Plus)
 		case percentPlaceholder:
 			w.WriteString(symb.Percent)
+		case perMillePlaceholder:
+			w.WriteString(symb.PerMille)
 		default:
 			w.WriteRune(ch)
 		}
@@ -42,7 +81,9 @@ func (w *writer) WriteAffix(affix string, symb *lxn.Symbols, currency string) {
 }
 
 func (w *writer) WriteInt(digits []rune, nf *lxn.NumberFormat) {
-	if nf.PrimaryIntegerGrouping > 0 {
+	// The digits are only grouped if the leading group has at least the minimum
+	// number of grouping digits, e.g. 1234 is not grouped for a minimum of two.
+	if nf.PrimaryIntegerGrouping > 0 && len(digits) >= nf.PrimaryIntegerGrouping+max(nf.MinGroupingDigits, 1) {
 		// secondary groups
 		lead := (len(digits) - nf.PrimaryIntegerGrouping) % nf.SecondaryIntegerGrouping
 		if lead > 0 {
@@ -78,6 +119,40 @@ func (w *writer) WriteFrac(digits []rune, nf *lxn.NumberFormat) {
 	w.WriteRunes(digits)
 }
 
+// WriteExponent writes the exponent of a number in scientific notation. If the
+// exponent should be superscripted, it will be written as a power of ten. The
+// superscripted digits are available for the latin digits only, for all other
+// digits the exponent is written with the regular digits.
+func (w *writer) WriteExponent(exp int, symb *lxn.Symbols, superscript bool) {
+	zero := rune(symb.Zero)
+	if superscript {
+		w.WriteString(symb.SuperscriptingExponent)
+		w.WriteRune(zero + 1)
+		w.WriteRune(zero)
+	} else {
+		w.WriteString(symb.Exponential)
+	}
+
+	if exp < 0 {
+		exp = -exp
+		if superscript {
+			w.WriteRune(superscriptMinus)
+		} else {
+			w.WriteString(symb.Minus)
+		}
+	}
+
+	var buf [maxIntDigits]rune
+	digits, _ := Uint(exp).digits(buf[:], &lxn.NumberFormat{}, 0)
+	for _, d := range digits {
+		if superscript && zero == '0' {
+			w.WriteRune(superscriptDigits[d])
+		} else {
+			w.WriteRune(zero + d)
+		}
+	}
+}
+
 func (w *writer) MissingVar(key string) {
 	w.WriteString("%!(MISSING:" + key + ")")
 }
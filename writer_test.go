@@ -45,6 +45,16 @@ func TestWriterWriteAffix(t *testing.T) {
 			symbols:  lxn.Symbols{Percent: "percent"},
 			expected: "foo_percent_bar",
 		},
+		{
+			affix:    "foo_" + string(perMillePlaceholder) + "_bar",
+			symbols:  lxn.Symbols{PerMille: "permille"},
+			expected: "foo_permille_bar",
+		},
+		{
+			affix:    "foo_" + string(plusPlaceholder) + "_bar",
+			symbols:  lxn.Symbols{Plus: "plus"},
+			expected: "foo_plus_bar",
+		},
 		{
 			affix: string(currencyPlaceholder) + "_foo_" + string(minusPlaceholder) + "_bar_" + string(percentPlaceholder),
 			symbols: lxn.Symbols{
@@ -114,6 +124,36 @@ func TestWriterWriteInt(t *testing.T) {
 			},
 			expected: "12#34#567",
 		},
+		{
+			digits: []rune{'1', '2', '3', '4'},
+			nf: lxn.NumberFormat{
+				Symbols:                  lxn.Symbols{Group: "#"},
+				PrimaryIntegerGrouping:   3,
+				SecondaryIntegerGrouping: 3,
+				MinGroupingDigits:        2,
+			},
+			expected: "1234",
+		},
+		{
+			digits: []rune{'1', '2', '3', '4', '5'},
+			nf: lxn.NumberFormat{
+				Symbols:                  lxn.Symbols{Group: "#"},
+				PrimaryIntegerGrouping:   3,
+				SecondaryIntegerGrouping: 3,
+				MinGroupingDigits:        2,
+			},
+			expected: "12#345",
+		},
+		{
+			digits: []rune{'1', '2', '3', '4', '5', '6', '7'},
+			nf: lxn.NumberFormat{
+				Symbols:                  lxn.Symbols{Group: "#"},
+				PrimaryIntegerGrouping:   3,
+				SecondaryIntegerGrouping: 3,
+				MinGroupingDigits:        2,
+			},
+			expected: "1#234#567",
+		},
 	}
 
 	for _, test := range tests {
@@ -178,6 +218,46 @@ func TestWriterWriteFrac(t *testing.T) {
 	}
 }
 
+func TestWriterWriteExponent(t *testing.T) {
+	tests := []struct {
+		exp         int
+		symbols     lxn.Symbols
+		superscript bool
+		expected    string
+	}{
+		{
+			exp:      12,
+			symbols:  lxn.Symbols{Zero: '0', Exponential: "E"},
+			expected: "E12",
+		},
+		{
+			exp:      -3,
+			symbols:  lxn.Symbols{Zero: '0', Exponential: "E", Minus: "~"},
+			expected: "E~3",
+		},
+		{
+			exp:         -12,
+			symbols:     lxn.Symbols{Zero: '0', SuperscriptingExponent: "×"},
+			superscript: true,
+			expected:    "×10⁻¹²",
+		},
+		{
+			exp:         5,
+			symbols:     lxn.Symbols{Zero: '٠', SuperscriptingExponent: "×"},
+			superscript: true,
+			expected:    "×١٠٥",
+		},
+	}
+
+	for _, test := range tests {
+		var w writer
+		w.WriteExponent(test.exp, &test.symbols, test.superscript)
+		if s := w.String(); s != test.expected {
+			t.Errorf("unexpected exponent for %q: %s", test.expected, s)
+		}
+	}
+}
+
 func TestWriterMissingVar(t *testing.T) {
 	var w writer
 	w.MissingVar("varkey")